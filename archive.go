@@ -0,0 +1,331 @@
+package figgo
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"slices"
+	"strings"
+	"time"
+)
+
+// packExts are the entry extensions a FontPack exposes
+var packExts = []string{".flf", ".tlf", ".flc"}
+
+// FontPack is a collection of fonts and control files read from a ZIP
+// archive, such as a distribution of a whole font collection.
+//
+// Every .flf, .tlf and .flc entry is exposed by its path in the archive;
+// other entries are ignored. FontPack implements fs.FS, fs.ReadFileFS and
+// fs.ReadDirFS, so it works with LoadFontFS, fs.WalkDir and fs.Glob.
+//
+// Entries are read and size-checked when the pack is loaded, applying the
+// same zip-bomb limits as compressed fonts:
+//   - The archive may be at most 5 MiB
+//   - Each entry may be at most 5 MiB uncompressed
+//   - All exposed entries together may be at most 64 MiB uncompressed
+//
+// A FontPack is immutable and safe for concurrent use across goroutines.
+//
+// Example:
+//
+//	pack, err := figgo.OpenFontArchive("figlet-fonts.zip")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	for _, name := range pack.Names() {
+//	    fmt.Println(name)
+//	}
+//	font, err := pack.LoadFont("slant")
+type FontPack struct {
+	files map[string]*packEntry
+	dirs  map[string][]string // directory path -> sorted child names
+	names []string            // sorted file paths
+}
+
+// packEntry is one file in a FontPack.
+type packEntry struct {
+	data    []byte
+	modTime time.Time
+}
+
+// LoadFontPack reads a font pack from a ZIP archive of the given size.
+//
+// Entries with unsafe paths (absolute, or containing "..") are rejected, as
+// are archives with duplicate entries or entries over the size limits.
+func LoadFontPack(r io.ReaderAt, size int64) (*FontPack, error) {
+	if size > maxZipSize {
+		return nil, fmt.Errorf("ZIP archive exceeds maximum size of %d bytes", maxZipSize)
+	}
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open ZIP archive: %w", err)
+	}
+	return readFontPack(zr, maxEntrySize, maxPackSize)
+}
+
+// OpenFontArchive reads a font pack from a ZIP file on the local filesystem.
+// This is a convenience wrapper around os.Open and LoadFontPack; the file is
+// closed before OpenFontArchive returns.
+func OpenFontArchive(path string) (*FontPack, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open %q: %w", path, err)
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return nil, fmt.Errorf("stat %q: %w", path, err)
+	}
+	pack, err := LoadFontPack(file, info.Size())
+	if err != nil {
+		return nil, fmt.Errorf("failed to load font archive %s: %w", path, err)
+	}
+	return pack, nil
+}
+
+// readFontPack reads the exposed entries of zr, enforcing the per-entry and
+// total size limits on the bytes actually decompressed.
+func readFontPack(zr *zip.Reader, maxEntry, maxTotal int64) (*FontPack, error) {
+	pack := &FontPack{
+		files: make(map[string]*packEntry),
+		dirs:  map[string][]string{".": nil},
+	}
+
+	var total int64
+	for _, f := range zr.File {
+		if f.FileInfo().IsDir() || !slices.Contains(packExts, strings.ToLower(path.Ext(f.Name))) {
+			continue
+		}
+		if !fs.ValidPath(f.Name) {
+			return nil, fmt.Errorf("unsafe entry path %q in ZIP archive", f.Name)
+		}
+		if _, dup := pack.files[f.Name]; dup {
+			return nil, fmt.Errorf("duplicate entry %q in ZIP archive", f.Name)
+		}
+		if f.UncompressedSize64 > uint64(maxEntry) {
+			return nil, fmt.Errorf("entry %q exceeds maximum size of %d bytes", f.Name, maxEntry)
+		}
+
+		data, err := readPackEntry(f, maxEntry)
+		if err != nil {
+			return nil, err
+		}
+		total += int64(len(data))
+		if total > maxTotal {
+			return nil, fmt.Errorf("ZIP archive contents exceed maximum total size of %d bytes", maxTotal)
+		}
+
+		pack.files[f.Name] = &packEntry{data: data, modTime: f.Modified}
+		pack.names = append(pack.names, f.Name)
+		pack.addToDirs(f.Name)
+	}
+
+	slices.Sort(pack.names)
+	for dir := range pack.dirs {
+		slices.Sort(pack.dirs[dir])
+	}
+	return pack, nil
+}
+
+// readPackEntry reads one entry, with a limit that holds regardless of the
+// size recorded in the archive.
+func readPackEntry(f *zip.File, maxEntry int64) ([]byte, error) {
+	rc, err := f.Open()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %q in ZIP: %w", f.Name, err)
+	}
+	defer rc.Close()
+
+	data, err := io.ReadAll(io.LimitReader(rc, maxEntry+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %q from ZIP: %w", f.Name, err)
+	}
+	if int64(len(data)) > maxEntry {
+		return nil, fmt.Errorf("entry %q exceeds maximum size of %d bytes", f.Name, maxEntry)
+	}
+	return data, nil
+}
+
+// addToDirs records name and each of its parent directories in their parents.
+func (p *FontPack) addToDirs(name string) {
+	for name != "." {
+		dir := path.Dir(name)
+		children, seen := p.dirs[dir]
+		base := path.Base(name)
+		if !slices.Contains(children, base) {
+			p.dirs[dir] = append(children, base)
+		}
+		if seen {
+			return
+		}
+		name = dir
+	}
+}
+
+// Names returns the paths of the fonts and control files in the pack, sorted.
+func (p *FontPack) Names() []string {
+	return slices.Clone(p.names)
+}
+
+// LoadFont loads a font from the pack. name is either an entry path or a
+// bare font name, which matches the first .flf (then .tlf) entry with that
+// base name in sorted path order, in any directory.
+func (p *FontPack) LoadFont(name string) (*Font, error) {
+	return LoadFontFS(p, p.resolve(name, ".flf", ".tlf"))
+}
+
+// LoadControlFile loads a control file from the pack. name is either an entry
+// path or a bare name, resolved like LoadFont with the .flc extension.
+func (p *FontPack) LoadControlFile(name string) (*ControlFile, error) {
+	resolved := p.resolve(name, ".flc")
+	data, err := p.ReadFile(resolved)
+	if err != nil {
+		return nil, err
+	}
+	cf, err := ParseControlFile(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse control file %s: %w", resolved, err)
+	}
+	cf.Name = strings.TrimSuffix(path.Base(resolved), path.Ext(resolved))
+	return cf, nil
+}
+
+// resolve maps a bare name to an entry path, trying each extension in turn.
+// Names that are already entry paths, or that match nothing, are returned
+// unchanged.
+func (p *FontPack) resolve(name string, exts ...string) string {
+	if _, ok := p.files[name]; ok {
+		return name
+	}
+	for _, ext := range exts {
+		for _, entry := range p.names {
+			if path.Base(entry) == name+ext {
+				return entry
+			}
+		}
+	}
+	return name
+}
+
+// Open implements fs.FS.
+func (p *FontPack) Open(name string) (fs.File, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrInvalid}
+	}
+	if e, ok := p.files[name]; ok {
+		return &packFile{
+			Reader: bytes.NewReader(e.data),
+			info:   packFileInfo{name: path.Base(name), size: int64(len(e.data)), modTime: e.modTime},
+		}, nil
+	}
+	if _, ok := p.dirs[name]; ok {
+		entries, _ := p.ReadDir(name)
+		return &packDir{
+			info:    packFileInfo{name: path.Base(name), mode: fs.ModeDir | 0o555},
+			entries: entries,
+		}, nil
+	}
+	return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+}
+
+// ReadFile implements fs.ReadFileFS. The returned slice is a copy.
+func (p *FontPack) ReadFile(name string) ([]byte, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "readfile", Path: name, Err: fs.ErrInvalid}
+	}
+	e, ok := p.files[name]
+	if !ok {
+		return nil, &fs.PathError{Op: "readfile", Path: name, Err: fs.ErrNotExist}
+	}
+	return slices.Clone(e.data), nil
+}
+
+// ReadDir implements fs.ReadDirFS.
+func (p *FontPack) ReadDir(name string) ([]fs.DirEntry, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: fs.ErrInvalid}
+	}
+	children, ok := p.dirs[name]
+	if !ok {
+		if _, isFile := p.files[name]; isFile {
+			return nil, &fs.PathError{Op: "readdir", Path: name, Err: errors.New("not a directory")}
+		}
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: fs.ErrNotExist}
+	}
+
+	entries := make([]fs.DirEntry, 0, len(children))
+	for _, child := range children {
+		full := path.Join(name, child)
+		info := packFileInfo{name: child, mode: fs.ModeDir | 0o555}
+		if e, isFile := p.files[full]; isFile {
+			info = packFileInfo{name: child, size: int64(len(e.data)), modTime: e.modTime}
+		}
+		entries = append(entries, fs.FileInfoToDirEntry(info))
+	}
+	return entries, nil
+}
+
+// packFileInfo implements fs.FileInfo for pack files and directories.
+type packFileInfo struct {
+	name    string
+	size    int64
+	mode    fs.FileMode
+	modTime time.Time
+}
+
+func (i packFileInfo) Name() string { return i.name }
+func (i packFileInfo) Size() int64  { return i.size }
+func (i packFileInfo) Mode() fs.FileMode {
+	if i.mode == 0 {
+		return 0o444
+	}
+	return i.mode
+}
+func (i packFileInfo) ModTime() time.Time { return i.modTime }
+func (i packFileInfo) IsDir() bool        { return i.mode.IsDir() }
+func (i packFileInfo) Sys() any           { return nil }
+
+// packFile is an open pack file.
+type packFile struct {
+	*bytes.Reader
+	info packFileInfo
+}
+
+func (f *packFile) Stat() (fs.FileInfo, error) { return f.info, nil }
+func (f *packFile) Close() error               { return nil }
+
+// packDir is an open pack directory.
+type packDir struct {
+	info    packFileInfo
+	entries []fs.DirEntry
+	offset  int
+}
+
+func (d *packDir) Stat() (fs.FileInfo, error) { return d.info, nil }
+func (d *packDir) Close() error               { return nil }
+
+func (d *packDir) Read([]byte) (int, error) {
+	return 0, &fs.PathError{Op: "read", Path: d.info.name, Err: errors.New("is a directory")}
+}
+
+// ReadDir implements fs.ReadDirFile.
+func (d *packDir) ReadDir(n int) ([]fs.DirEntry, error) {
+	remaining := d.entries[d.offset:]
+	if n <= 0 {
+		d.offset = len(d.entries)
+		return slices.Clone(remaining), nil
+	}
+	if len(remaining) == 0 {
+		return nil, io.EOF
+	}
+	n = min(n, len(remaining))
+	d.offset += n
+	return slices.Clone(remaining[:n]), nil
+}
@@ -0,0 +1,207 @@
+package figgo
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+// buildZip returns a ZIP archive holding files, written in the given order.
+func buildZip(t *testing.T, files ...[2]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	for _, f := range files {
+		fw, err := w.Create(f[0])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := fw.Write([]byte(f[1])); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+// buildTestPack returns a pack with fonts at the top level and in a
+// subdirectory, a control file and an entry the pack ignores.
+func buildTestPack(t *testing.T) []byte {
+	t.Helper()
+	standard, err := os.ReadFile(filepath.Join("fonts", "standard.flf"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return buildZip(t,
+		[2]string{"README.txt", "not a font"},
+		[2]string{"standard.flf", string(standard)},
+		[2]string{"contrib/mini.flf", minimalFont},
+		[2]string{"contrib/future.tlf", buildTLFFont()},
+		[2]string{"contrib/upper.flc", "flc2a\nt a-z A-Z\n"},
+	)
+}
+
+func TestLoadFontPack(t *testing.T) {
+	data := buildTestPack(t)
+	pack, err := LoadFontPack(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatalf("LoadFontPack() error = %v", err)
+	}
+
+	wantNames := []string{"contrib/future.tlf", "contrib/mini.flf", "contrib/upper.flc", "standard.flf"}
+	if got := pack.Names(); !reflect.DeepEqual(got, wantNames) {
+		t.Errorf("Names() = %v, want %v", got, wantNames)
+	}
+
+	if err := fstest.TestFS(pack, wantNames...); err != nil {
+		t.Errorf("fstest.TestFS() error = %v", err)
+	}
+	if _, err := fs.Stat(pack, "README.txt"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("Stat(README.txt) error = %v, want fs.ErrNotExist", err)
+	}
+
+	fonts := []struct {
+		name     string
+		wantName string
+		height   int
+	}{
+		{name: "standard", wantName: "standard", height: 6},
+		{name: "mini", wantName: "mini", height: 3},
+		{name: "contrib/mini.flf", wantName: "mini", height: 3},
+		{name: "future", wantName: "future", height: 1},
+	}
+	for _, tt := range fonts {
+		t.Run(tt.name, func(t *testing.T) {
+			font, err := pack.LoadFont(tt.name)
+			if err != nil {
+				t.Fatalf("LoadFont(%q) error = %v", tt.name, err)
+			}
+			if font.Name != tt.wantName || font.Height != tt.height {
+				t.Errorf("LoadFont(%q) = %q with height %d, want %q with height %d",
+					tt.name, font.Name, font.Height, tt.wantName, tt.height)
+			}
+		})
+	}
+
+	if _, err := pack.LoadFont("missing"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("LoadFont(missing) error = %v, want fs.ErrNotExist", err)
+	}
+
+	cf, err := pack.LoadControlFile("upper")
+	if err != nil {
+		t.Fatalf("LoadControlFile() error = %v", err)
+	}
+	if cf.Name != "upper" {
+		t.Errorf("ControlFile.Name = %q, want %q", cf.Name, "upper")
+	}
+}
+
+func TestOpenFontArchive(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "pack.zip")
+	if err := os.WriteFile(path, buildTestPack(t), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	pack, err := OpenFontArchive(path)
+	if err != nil {
+		t.Fatalf("OpenFontArchive() error = %v", err)
+	}
+	if len(pack.Names()) != 4 {
+		t.Errorf("Names() = %v, want 4 entries", pack.Names())
+	}
+
+	_, err = OpenFontArchive(filepath.Join(t.TempDir(), "missing.zip"))
+	if !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("OpenFontArchive() error = %v, want os.ErrNotExist", err)
+	}
+}
+
+func TestLoadFontPackErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    []byte
+		size    int64
+		wantErr string
+	}{
+		{
+			name:    "not a ZIP archive",
+			data:    []byte(minimalFont),
+			wantErr: "failed to open ZIP archive",
+		},
+		{
+			name:    "archive too large",
+			data:    []byte("PK\x03\x04"),
+			size:    maxZipSize + 1,
+			wantErr: "ZIP archive exceeds maximum size",
+		},
+		{
+			name:    "unsafe path",
+			data:    buildZip(t, [2]string{"../evil.flf", minimalFont}),
+			wantErr: `unsafe entry path "../evil.flf"`,
+		},
+		{
+			name:    "duplicate entry",
+			data:    buildZip(t, [2]string{"a.flf", minimalFont}, [2]string{"a.flf", minimalFont}),
+			wantErr: `duplicate entry "a.flf"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			size := tt.size
+			if size == 0 {
+				size = int64(len(tt.data))
+			}
+			_, err := LoadFontPack(bytes.NewReader(tt.data), size)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("LoadFontPack() error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestReadFontPackLimits(t *testing.T) {
+	data := buildZip(t,
+		[2]string{"a.flf", strings.Repeat("a", 100)},
+		[2]string{"b.flf", strings.Repeat("b", 100)},
+	)
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		maxEntry int64
+		maxTotal int64
+		wantErr  string
+	}{
+		{name: "within limits", maxEntry: 100, maxTotal: 200},
+		{name: "entry limit", maxEntry: 99, maxTotal: 200, wantErr: `entry "a.flf" exceeds maximum size of 99 bytes`},
+		{name: "total limit", maxEntry: 100, maxTotal: 199, wantErr: "exceed maximum total size of 199 bytes"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := readFontPack(zr, tt.maxEntry, tt.maxTotal)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("readFontPack() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("readFontPack() error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package figgo
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestWithBalancedWrap(t *testing.T) {
+	font, err := LoadFont("fonts/standard.flf")
+	if err != nil {
+		t.Fatalf("LoadFont() error = %v", err)
+	}
+
+	tests := []struct {
+		name string
+		text string
+		// same renders like the balanced text, with its breaks made explicit
+		same string
+	}{
+		{name: "even halves", text: "Hello big wide world", same: "Hello big\nwide world"},
+		{name: "three lines", text: "Welcome to the new release of figgo", same: "Welcome\nto the new\nrelease of figgo"},
+		{name: "fits on one line", text: "Hi there", same: "Hi there"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Render(tt.text, font, WithWidth(80), WithBalancedWrap(true))
+			if err != nil {
+				t.Fatalf("Render() error = %v", err)
+			}
+			want, err := Render(tt.same, font, WithWidth(80))
+			if err != nil {
+				t.Fatalf("Render() error = %v", err)
+			}
+			if got != want {
+				t.Errorf("Render() =\n%s\nwant:\n%s", got, want)
+			}
+
+			// Deterministic across runs
+			again, err := Render(tt.text, font, WithWidth(80), WithBalancedWrap(true))
+			if err != nil || again != got {
+				t.Errorf("second Render() differs (err = %v)", err)
+			}
+		})
+	}
+
+	// Balancing never adds lines
+	greedy, err := Render("Welcome to the new release of figgo", font, WithWidth(80))
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	balanced, err := Render("Welcome to the new release of figgo", font, WithWidth(80), WithBalancedWrap(true))
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	if strings.Count(balanced, "\n") != strings.Count(greedy, "\n") {
+		t.Errorf("balanced output has %d rows, greedy %d", strings.Count(balanced, "\n")+1, strings.Count(greedy, "\n")+1)
+	}
+}
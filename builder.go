@@ -0,0 +1,179 @@
+package figgo
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/ryanlewis/figgo/internal/parser"
+)
+
+// FontBuilder assembles a Font in code, without writing and parsing a .flf file.
+//
+// Glyph rows are validated the same way the parser validates rows read from a
+// file:
+//   - Each glyph must have exactly height rows
+//   - Rows must not contain line breaks
+//   - Shorter rows are padded with spaces to the width of the widest row
+//
+// Setters record the first error they hit; Build reports it. The layout
+// defaults to FitFullWidth and the print direction to left-to-right.
+//
+// A FontBuilder is not safe for concurrent use, but the Fonts it builds are
+// immutable and independent of the builder.
+//
+// Example:
+//
+//	font, err := figgo.NewFontBuilder(2, 2, '$').
+//	    SetGlyph(' ', []string{"$", "$"}).
+//	    SetGlyph('I', []string{"#", "#"}).
+//	    SetLayout(figgo.FitKerning).
+//	    Build()
+type FontBuilder struct {
+	name           string
+	height         int
+	baseline       int
+	hardblank      rune
+	layout         Layout
+	printDirection int
+	comments       []string
+	glyphs         map[rune][]string
+	err            error
+}
+
+// NewFontBuilder returns a builder for a font whose glyphs are height rows
+// tall, with the given baseline (1..height) and hardblank character.
+func NewFontBuilder(height, baseline int, hardblank rune) *FontBuilder {
+	b := &FontBuilder{
+		height:    height,
+		baseline:  baseline,
+		hardblank: hardblank,
+		layout:    FitFullWidth,
+		glyphs:    make(map[rune][]string),
+	}
+
+	switch {
+	case height <= 0:
+		b.setErr(fmt.Errorf("height must be positive, got %d", height))
+	case baseline < 1 || baseline > height:
+		b.setErr(fmt.Errorf("baseline must be between 1 and height (%d), got %d", height, baseline))
+	case hardblank == ' ' || hardblank == '\r' || hardblank == '\n' || hardblank == 0 ||
+		!utf8.ValidRune(hardblank):
+		b.setErr(fmt.Errorf("invalid hardblank character %q", hardblank))
+	}
+	return b
+}
+
+// SetName sets the font name reported by Font.Name.
+func (b *FontBuilder) SetName(name string) *FontBuilder {
+	b.name = name
+	return b
+}
+
+// SetGlyph sets the rows for r, replacing any previous glyph. The rows are
+// copied, so the caller may reuse the slice. Code -1 is illegal per the
+// FIGfont spec; other negative codes and code 0 (the missing character) are
+// allowed.
+func (b *FontBuilder) SetGlyph(r rune, rows []string) *FontBuilder {
+	if r == -1 {
+		b.setErr(errors.New("character code -1 is illegal"))
+		return b
+	}
+	glyph, err := parser.NormalizeGlyph(rows, b.height)
+	if err != nil {
+		b.setErr(fmt.Errorf("glyph %q: %w", r, err))
+		return b
+	}
+	b.glyphs[r] = glyph
+	return b
+}
+
+// SetLayout sets the font's default layout. It is validated with
+// NormalizeLayout, so conflicting fitting modes are reported by Build.
+func (b *FontBuilder) SetLayout(layout Layout) *FontBuilder {
+	normalized, err := NormalizeLayout(layout)
+	if err != nil {
+		b.setErr(err)
+		return b
+	}
+	b.layout = normalized
+	return b
+}
+
+// SetPrintDirection sets the default print direction (0=LTR, 1=RTL).
+func (b *FontBuilder) SetPrintDirection(direction int) *FontBuilder {
+	if direction != 0 && direction != 1 {
+		b.setErr(fmt.Errorf("invalid print direction: %d (must be 0 or 1)", direction))
+		return b
+	}
+	b.printDirection = direction
+	return b
+}
+
+// SetComments sets the font's header comment lines, replacing any previous ones.
+func (b *FontBuilder) SetComments(comments ...string) *FontBuilder {
+	for i, c := range comments {
+		if strings.ContainsAny(c, "\r\n") {
+			b.setErr(fmt.Errorf("comment line %d contains a line break", i+1))
+			return b
+		}
+	}
+	b.comments = slices.Clone(comments)
+	return b
+}
+
+// Build returns the font, or the first error recorded by the builder wrapped
+// in ErrBadFontFormat. MaxLen is the widest glyph plus two, the usual room
+// for endmarks, and OldLayout is derived from the layout.
+func (b *FontBuilder) Build() (*Font, error) {
+	if b.err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrBadFontFormat, b.err)
+	}
+
+	maxWidth := 0
+	for _, glyph := range b.glyphs {
+		if len(glyph) > 0 {
+			maxWidth = max(maxWidth, utf8.RuneCountInString(glyph[0]))
+		}
+	}
+
+	return &Font{
+		glyphs:         cloneGlyphs(b.glyphs),
+		Name:           b.name,
+		Layout:         b.layout,
+		Hardblank:      b.hardblank,
+		Height:         b.height,
+		Baseline:       b.baseline,
+		MaxLen:         maxWidth + 2,
+		OldLayout:      oldLayoutFromLayout(b.layout),
+		PrintDirection: b.printDirection,
+		CommentLines:   len(b.comments),
+		comments:       slices.Clone(b.comments),
+		fullLayout:     int(b.layout & AllKnownMask),
+		fullLayoutSet:  true,
+	}, nil
+}
+
+// setErr records err unless an earlier error is already recorded.
+func (b *FontBuilder) setErr(err error) {
+	if b.err == nil {
+		b.err = err
+	}
+}
+
+// oldLayoutFromLayout returns the OldLayout header value for a layout:
+// -1 for full width, 0 for kerning and the rule bits for smushing. Universal
+// smushing cannot be expressed in OldLayout and maps to kerning, as older
+// FIGlet versions would treat it; the full layout keeps the exact mode.
+func oldLayoutFromLayout(layout Layout) int {
+	switch layout.FittingMode() {
+	case FitKerning:
+		return 0
+	case FitSmushing:
+		return int(layout.Rules())
+	default:
+		return -1
+	}
+}
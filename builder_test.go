@@ -0,0 +1,165 @@
+package figgo
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestFontBuilder(t *testing.T) {
+	rows := []string{"/\\", "\\/"}
+	font, err := NewFontBuilder(2, 2, '$').
+		SetName("diamond").
+		SetComments("Generated in code", "by a test").
+		SetGlyph(' ', []string{"$", "$"}).
+		SetGlyph('O', rows).
+		SetGlyph('I', []string{"|", ""}).
+		SetLayout(FitSmushing | RuleEqualChar).
+		Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+
+	// Mutating the caller's slice must not affect the built font
+	rows[0] = "XX"
+
+	if font.Name != "diamond" {
+		t.Errorf("Name = %q, want %q", font.Name, "diamond")
+	}
+	if font.Height != 2 || font.Baseline != 2 || font.Hardblank != '$' {
+		t.Errorf("Height/Baseline/Hardblank = %d/%d/%q, want 2/2/'$'", font.Height, font.Baseline, font.Hardblank)
+	}
+	if font.Layout != FitSmushing|RuleEqualChar {
+		t.Errorf("Layout = %v, want %v", font.Layout, FitSmushing|RuleEqualChar)
+	}
+	if font.OldLayout != 1 {
+		t.Errorf("OldLayout = %d, want 1", font.OldLayout)
+	}
+	if font.MaxLen != 4 {
+		t.Errorf("MaxLen = %d, want 4", font.MaxLen)
+	}
+	if font.CommentLines != 2 {
+		t.Errorf("CommentLines = %d, want 2", font.CommentLines)
+	}
+
+	if got, _ := font.Glyph('O'); !reflect.DeepEqual(got, []string{"/\\", "\\/"}) {
+		t.Errorf("Glyph('O') = %q, want %q", got, []string{"/\\", "\\/"})
+	}
+	// Short rows are padded to the widest row, as the parser does
+	if got, _ := font.Glyph('I'); !reflect.DeepEqual(got, []string{"|", " "}) {
+		t.Errorf("Glyph('I') = %q, want %q", got, []string{"|", " "})
+	}
+
+	out, err := Render("OIO", font)
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	if want := "/\\|/\\\n\\/ \\/"; out != want {
+		t.Errorf("Render() = %q, want %q", out, want)
+	}
+}
+
+func TestFontBuilderBuildIsIndependent(t *testing.T) {
+	b := NewFontBuilder(1, 1, '$').SetGlyph('A', []string{"A"})
+	first, err := b.Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+
+	b.SetGlyph('A', []string{"a"}).SetGlyph('B', []string{"B"})
+	if got, _ := first.Glyph('A'); got[0] != "A" {
+		t.Errorf("earlier font changed after SetGlyph: Glyph('A') = %q", got)
+	}
+	if _, ok := first.Glyph('B'); ok {
+		t.Error("earlier font gained a glyph added after Build")
+	}
+}
+
+func TestFontBuilderLayoutDefaults(t *testing.T) {
+	tests := []struct {
+		layout        Layout
+		wantOldLayout int
+	}{
+		{FitFullWidth, -1},
+		{FitKerning, 0},
+		{FitSmushing, 0},
+		{FitSmushing | RuleHierarchy | RuleHardblank, 36},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.layout.String(), func(t *testing.T) {
+			font, err := NewFontBuilder(1, 1, '$').SetGlyph(' ', []string{"$"}).SetLayout(tt.layout).Build()
+			if err != nil {
+				t.Fatalf("Build() error = %v", err)
+			}
+			if font.OldLayout != tt.wantOldLayout {
+				t.Errorf("OldLayout = %d, want %d", font.OldLayout, tt.wantOldLayout)
+			}
+
+			// The layout must survive a write/parse round trip
+			font = roundTripFont(t, font)
+			if font.Layout != tt.layout {
+				t.Errorf("Layout after round trip = %v, want %v", font.Layout, tt.layout)
+			}
+		})
+	}
+}
+
+// roundTripFont writes font as .flf and parses it back.
+func roundTripFont(t *testing.T, font *Font) *Font {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := EncodeFont(&buf, font); err != nil {
+		t.Fatalf("EncodeFont() error = %v", err)
+	}
+	parsed, err := ParseFont(&buf)
+	if err != nil {
+		t.Fatalf("ParseFont() error = %v", err)
+	}
+	return parsed
+}
+
+func TestFontBuilderErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		build func() (*Font, error)
+	}{
+		{"zero height", func() (*Font, error) { return NewFontBuilder(0, 1, '$').Build() }},
+		{"baseline above height", func() (*Font, error) { return NewFontBuilder(2, 3, '$').Build() }},
+		{"space hardblank", func() (*Font, error) { return NewFontBuilder(1, 1, ' ').Build() }},
+		{"too few rows", func() (*Font, error) {
+			return NewFontBuilder(2, 1, '$').SetGlyph('A', []string{"A"}).Build()
+		}},
+		{"too many rows", func() (*Font, error) {
+			return NewFontBuilder(1, 1, '$').SetGlyph('A', []string{"A", "A"}).Build()
+		}},
+		{"row with newline", func() (*Font, error) {
+			return NewFontBuilder(1, 1, '$').SetGlyph('A', []string{"A\nA"}).Build()
+		}},
+		{"illegal code", func() (*Font, error) {
+			return NewFontBuilder(1, 1, '$').SetGlyph(-1, []string{"A"}).Build()
+		}},
+		{"layout conflict", func() (*Font, error) {
+			return NewFontBuilder(1, 1, '$').SetLayout(FitKerning | FitSmushing).Build()
+		}},
+		{"bad print direction", func() (*Font, error) {
+			return NewFontBuilder(1, 1, '$').SetPrintDirection(2).Build()
+		}},
+		{"comment with newline", func() (*Font, error) {
+			return NewFontBuilder(1, 1, '$').SetComments("a\nb").Build()
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			font, err := tt.build()
+			if !errors.Is(err, ErrBadFontFormat) {
+				t.Errorf("Build() error = %v, want ErrBadFontFormat", err)
+			}
+			if font != nil {
+				t.Error("Build() returned a font alongside an error")
+			}
+		})
+	}
+}
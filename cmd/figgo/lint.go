@@ -0,0 +1,260 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ryanlewis/figgo"
+	"github.com/spf13/pflag"
+)
+
+// Kinds reported by lint for problems outside the font parser's diagnostics
+const (
+	lintKindFileError          = "file-error"
+	lintKindInvalidControlFile = "invalid-control-file"
+)
+
+// lintErrorKinds are diagnostics the parser recovers from that lint still
+// reports as errors, as the font is missing glyphs or its header does not
+// match the file.
+var lintErrorKinds = map[figgo.DiagnosticKind]bool{
+	figgo.KindTruncatedFont:        true,
+	figgo.KindMissingDeutsch:       true,
+	figgo.KindTruncatedGlyph:       true,
+	figgo.KindCodetagCountMismatch: true,
+	figgo.KindTrailingData:         true,
+}
+
+// lintFinding is one problem reported by figgo lint.
+type lintFinding struct {
+	File     string `json:"file"`
+	Line     int    `json:"line,omitempty"`
+	Char     *rune  `json:"char,omitempty"`
+	Severity string `json:"severity"`
+	Kind     string `json:"kind"`
+	Message  string `json:"message"`
+}
+
+// String formats the finding like a compiler message: "file:line: char C: severity: message (kind)".
+func (f lintFinding) String() string {
+	s := f.File + ":"
+	if f.Line > 0 {
+		s += fmt.Sprintf("%d:", f.Line)
+	}
+	if f.Char != nil {
+		s += fmt.Sprintf(" char %d:", *f.Char)
+	}
+	return fmt.Sprintf("%s %s: %s (%s)", s, f.Severity, f.Message, f.Kind)
+}
+
+// runLint implements "figgo lint". It checks each font (.flf, .tlf, or a ZIP
+// compressed font), control file (.flc) and font archive (.zip) given in
+// args, reports every problem found, and returns 1 if any file has errors.
+// With --strict, warnings count as errors.
+func runLint(args []string, stdout, stderr io.Writer) int {
+	var (
+		format   string
+		strict   bool
+		showHelp bool
+	)
+
+	flags := pflag.NewFlagSet("lint", pflag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.StringVar(&format, "format", "text", "Output format: text or json")
+	flags.BoolVar(&strict, "strict", false, "Treat warnings as errors")
+	flags.BoolVarP(&showHelp, "help", "h", false, "Show help message")
+	flags.Usage = func() {}
+	if err := flags.Parse(args); err != nil {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return 1
+	}
+
+	if showHelp {
+		printLintHelp(stdout, flags)
+		return 0
+	}
+	if format != "text" && format != "json" {
+		fmt.Fprintf(stderr, "Error: unknown format %q (want text or json)\n", format)
+		return 1
+	}
+	paths := flags.Args()
+	if len(paths) == 0 {
+		fmt.Fprintln(stderr, "Error: no files provided")
+		printLintHelp(stderr, flags)
+		return 1
+	}
+
+	findings := []lintFinding{}
+	for _, path := range paths {
+		findings = append(findings, lintFile(path, strict)...)
+	}
+
+	errorCount := 0
+	for _, f := range findings {
+		if f.Severity == figgo.SeverityError.String() {
+			errorCount++
+		}
+	}
+
+	if format == "json" {
+		enc := json.NewEncoder(stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(findings); err != nil {
+			fmt.Fprintf(stderr, "Error writing output: %v\n", err)
+			return 1
+		}
+	} else {
+		for _, f := range findings {
+			fmt.Fprintln(stdout, f)
+		}
+		fmt.Fprintf(stdout, "%d files checked, %d errors, %d warnings\n",
+			len(paths), errorCount, len(findings)-errorCount)
+	}
+
+	if errorCount > 0 {
+		return 1
+	}
+	return 0
+}
+
+// lintFile checks a single file, choosing the checks by its extension.
+func lintFile(path string, strict bool) []lintFinding {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".flc":
+		return lintControlFile(path)
+	case ".zip":
+		return lintArchive(path, strict)
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return []lintFinding{fileError(path, lintKindFileError, err)}
+	}
+	defer file.Close()
+	return lintFont(path, file, strict)
+}
+
+// lintArchive checks every font and control file in a font archive, naming
+// them "archive.zip:entry".
+func lintArchive(path string, strict bool) []lintFinding {
+	pack, err := figgo.OpenFontArchive(path)
+	if err != nil {
+		return []lintFinding{fileError(path, lintKindFileError, err)}
+	}
+
+	var findings []lintFinding
+	for _, name := range pack.Names() {
+		entry := path + ":" + name
+		data, err := pack.ReadFile(name)
+		if err != nil {
+			findings = append(findings, fileError(entry, lintKindFileError, err))
+			continue
+		}
+		if strings.EqualFold(filepath.Ext(name), ".flc") {
+			findings = append(findings, lintControl(entry, bytes.NewReader(data))...)
+		} else {
+			findings = append(findings, lintFont(entry, bytes.NewReader(data), strict)...)
+		}
+	}
+	return findings
+}
+
+// lintFont reports the parser diagnostics for a font. Fatal problems, missing
+// glyphs, header counts that do not match the file and, in strict mode, every
+// diagnostic are errors.
+func lintFont(name string, r io.Reader, strict bool) []lintFinding {
+	font, err := figgo.ParseFontWithOptions(r, figgo.ParseOptions{Strict: strict})
+	if err != nil {
+		var fe *figgo.FontError
+		if !errors.As(err, &fe) {
+			return []lintFinding{fileError(name, lintKindFileError, err)}
+		}
+		return diagnosticFindings(name, fe.Diagnostics)
+	}
+	return diagnosticFindings(name, font.Diagnostics())
+}
+
+// lintControlFile reports whether a control file parses.
+func lintControlFile(path string) []lintFinding {
+	file, err := os.Open(path)
+	if err != nil {
+		return []lintFinding{fileError(path, lintKindFileError, err)}
+	}
+	defer file.Close()
+	return lintControl(path, file)
+}
+
+// lintControl reports whether the control file read from r parses.
+func lintControl(name string, r io.Reader) []lintFinding {
+	if _, err := figgo.ParseControlFile(r); err != nil {
+		return []lintFinding{fileError(name, lintKindInvalidControlFile, err)}
+	}
+	return nil
+}
+
+// diagnosticFindings converts font diagnostics to findings for path.
+func diagnosticFindings(path string, diags []figgo.Diagnostic) []lintFinding {
+	findings := make([]lintFinding, 0, len(diags))
+	for _, d := range diags {
+		severity := d.Severity
+		if lintErrorKinds[d.Kind] {
+			severity = figgo.SeverityError
+		}
+		f := lintFinding{
+			File:     path,
+			Line:     d.Line,
+			Severity: severity.String(),
+			Kind:     string(d.Kind),
+			Message:  lintMessage(d),
+		}
+		if d.HasCode {
+			code := d.Code
+			f.Char = &code
+		}
+		findings = append(findings, f)
+	}
+	return findings
+}
+
+// lintMessage returns the message for a diagnostic. The parser numbers the
+// rows of a glyph as lines, which reads as a file line after the finding's
+// location, so lint names them rows.
+func lintMessage(d figgo.Diagnostic) string {
+	var row, width, maxLength int
+	if d.Kind == figgo.KindMaxLengthExceeded {
+		if _, err := fmt.Sscanf(d.Message, "line %d width (%d) exceeds MaxLength (%d)", &row, &width, &maxLength); err == nil {
+			return fmt.Sprintf("row %d of the glyph is %d columns wide, over MaxLength %d", row, width, maxLength)
+		}
+	}
+	return d.Message
+}
+
+// fileError builds an error finding for a file that could not be checked.
+func fileError(path, kind string, err error) lintFinding {
+	return lintFinding{
+		File:     path,
+		Severity: figgo.SeverityError.String(),
+		Kind:     kind,
+		Message:  err.Error(),
+	}
+}
+
+func printLintHelp(w io.Writer, flags *pflag.FlagSet) {
+	fmt.Fprintln(w, "figgo lint - check FIGlet font and control files")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Usage:")
+	fmt.Fprintln(w, "  figgo lint [flags] <file>...")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Files are fonts (.flf, .tlf, or ZIP compressed), control files (.flc)")
+	fmt.Fprintln(w, "or font archives (.zip), whose entries are reported as archive.zip:entry.")
+	fmt.Fprintln(w, "Missing glyphs and header counts that do not match the file are errors.")
+	fmt.Fprintln(w, "Exits with status 1 if any file has errors.")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Flags:")
+	fmt.Fprint(w, flags.FlagUsages())
+}
@@ -0,0 +1,218 @@
+package main
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeLintFile writes content to name in dir and returns its path.
+func writeLintFile(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// lintTestFont returns a 1-row font with the ASCII characters but no Deutsch
+// characters; the file ends on line 96.
+func lintTestFont(header string) string {
+	var sb strings.Builder
+	sb.WriteString(header + "\n")
+	for i := 32; i <= 126; i++ {
+		sb.WriteString("x@@\n")
+	}
+	return sb.String()
+}
+
+// lintTestArchive returns a ZIP archive holding files.
+func lintTestArchive(t *testing.T, files map[string]string) string {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, content := range files {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.String()
+}
+
+func TestRunLint(t *testing.T) {
+	dir := t.TempDir()
+	standard := filepath.Join(projectRoot(), "fonts", "standard.flf")
+	partial := writeLintFile(t, dir, "partial.flf", lintTestFont("flf2a$ 1 1 3 -1 0"))
+	mismatch := writeLintFile(t, dir, "mismatch.flf", lintTestFont("flf2a$ 1 1 3 -1 0 0 64 0")+strings.Repeat("x@@\n", 7))
+	truncated := writeLintFile(t, dir, "truncated.flf", "flf2a$ 2 2 3 -1 0\nx@\n")
+	wide := writeLintFile(t, dir, "wide.flf", strings.Replace(lintTestFont("flf2a$ 1 1 3 -1 0"), "x@@", "xxxxx@@", 1)+strings.Repeat("x@@\n", 7))
+	cutOff := writeLintFile(t, dir, "cutoff.flf", "flf2a$ 1 1 3 -1 0\n"+strings.Repeat("x@@\n", 10))
+	goodControl := writeLintFile(t, dir, "upper.flc", "flc2a\nt a-z A-Z\n")
+	badControl := writeLintFile(t, dir, "bad.flc", "flc2a\nz\n")
+	archive := writeLintFile(t, dir, "pack.zip", lintTestArchive(t, map[string]string{
+		"fonts/partial.flf": lintTestFont("flf2a$ 1 1 3 -1 0"),
+		"bad.flc":           "flc2a\nz\n",
+		"notes.txt":         "ignored",
+	}))
+
+	tests := []struct {
+		name     string
+		args     []string
+		wantExit int
+		want     []string
+	}{
+		{
+			name:     "clean files",
+			args:     []string{standard, goodControl},
+			wantExit: 0,
+			want:     []string{"2 files checked, 0 errors, 0 warnings"},
+		},
+		{
+			name:     "warnings do not fail",
+			args:     []string{mismatch},
+			wantExit: 0,
+			want: []string{
+				mismatch + ":1: warning: old layout -1 disagrees with full layout 64; full layout is used (layout-mismatch)",
+				"1 files checked, 0 errors, 1 warnings",
+			},
+		},
+		{
+			name:     "glyph rows wider than MaxLength",
+			args:     []string{wide},
+			wantExit: 0,
+			want:     []string{wide + ":2: char 32: warning: row 1 of the glyph is 5 columns wide, over MaxLength 3 (maxlength-exceeded)"},
+		},
+		{
+			name:     "strict fails on warnings",
+			args:     []string{"--strict", mismatch},
+			wantExit: 1,
+			want:     []string{mismatch + ":1: error: old layout -1 disagrees with full layout 64; full layout is used (layout-mismatch)"},
+		},
+		{
+			name:     "missing Deutsch characters fail",
+			args:     []string{partial},
+			wantExit: 1,
+			want: []string{
+				partial + ":96: char 196: error: font ends before Deutsch character 196 (missing-deutsch)",
+				"1 files checked, 1 errors, 0 warnings",
+			},
+		},
+		{
+			name:     "font cut off between characters fails",
+			args:     []string{cutOff},
+			wantExit: 1,
+			want: []string{
+				cutOff + ":11: char 42: error: font ends before character 42 (*) (truncated-font)",
+				"1 files checked, 1 errors, 0 warnings",
+			},
+		},
+		{
+			name:     "fatal font errors",
+			args:     []string{truncated},
+			wantExit: 1,
+			want:     []string{truncated + ":2: char 32: error: ", "(truncated-font)"},
+		},
+		{
+			name:     "invalid control file",
+			args:     []string{badControl},
+			wantExit: 1,
+			want:     []string{"line 2: unknown command", "(invalid-control-file)"},
+		},
+		{
+			name:     "font archive entries",
+			args:     []string{archive},
+			wantExit: 1,
+			want: []string{
+				archive + ":bad.flc: error: ",
+				archive + ":fonts/partial.flf:96: char 196: error: ",
+				"1 files checked, 2 errors, 0 warnings",
+			},
+		},
+		{
+			name:     "missing file",
+			args:     []string{filepath.Join(dir, "missing.flf")},
+			wantExit: 1,
+			want:     []string{"(file-error)", "1 files checked, 1 errors, 0 warnings"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			if got := runLint(tt.args, &stdout, &stderr); got != tt.wantExit {
+				t.Errorf("runLint() = %d, want %d\nstdout: %s\nstderr: %s", got, tt.wantExit, &stdout, &stderr)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(stdout.String(), want) {
+					t.Errorf("output missing %q:\n%s", want, &stdout)
+				}
+			}
+		})
+	}
+}
+
+func TestRunLintJSON(t *testing.T) {
+	dir := t.TempDir()
+	partial := writeLintFile(t, dir, "partial.flf", lintTestFont("flf2a$ 1 1 3 -1 0"))
+
+	var stdout, stderr bytes.Buffer
+	if got := runLint([]string{"--format", "json", partial}, &stdout, &stderr); got != 1 {
+		t.Fatalf("runLint() = %d, want 1 (stderr: %s)", got, &stderr)
+	}
+
+	var findings []lintFinding
+	if err := json.Unmarshal(stdout.Bytes(), &findings); err != nil {
+		t.Fatalf("invalid JSON output: %v\n%s", err, &stdout)
+	}
+	if len(findings) != 1 {
+		t.Fatalf("got %d findings, want 1: %+v", len(findings), findings)
+	}
+	f := findings[0]
+	if f.File != partial || f.Line != 96 || f.Char == nil || *f.Char != 196 ||
+		f.Severity != "error" || f.Kind != "missing-deutsch" {
+		t.Errorf("finding = %+v", f)
+	}
+
+	// Clean files produce an empty array, not null
+	stdout.Reset()
+	runLint([]string{"--format=json", filepath.Join(projectRoot(), "fonts", "small.flf")}, &stdout, &stderr)
+	if got := strings.TrimSpace(stdout.String()); got != "[]" {
+		t.Errorf("clean output = %q, want []", got)
+	}
+}
+
+func TestRunLintUsageErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{name: "no files", args: nil, want: "no files provided"},
+		{name: "unknown format", args: []string{"--format", "xml", "a.flf"}, want: `unknown format "xml"`},
+		{name: "unknown flag", args: []string{"--bogus"}, want: "unknown flag"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			if got := runLint(tt.args, &stdout, &stderr); got != 1 {
+				t.Errorf("runLint() = %d, want 1", got)
+			}
+			if !strings.Contains(stderr.String(), tt.want) {
+				t.Errorf("stderr = %q, want it to contain %q", &stderr, tt.want)
+			}
+		})
+	}
+}
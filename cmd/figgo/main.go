@@ -2,7 +2,10 @@
 package main
 
 import (
+	"errors"
 	"fmt"
+	"image"
+	"image/png"
 	"io"
 	"os"
 	"path/filepath"
@@ -26,8 +29,22 @@ func main() {
 }
 
 func run() int {
+	// Subcommands are dispatched before flag parsing; "figgo -- lint"
+	// still renders the word
+	if len(os.Args) > 1 && os.Args[1] == "lint" {
+		return runLint(os.Args[2:], os.Stdout, os.Stderr)
+	}
+	// "figgo comment" renders the banner inside a source code comment
+	cliArgs := os.Args[1:]
+	commentMode := false
+	if len(cliArgs) > 0 && cliArgs[0] == "comment" {
+		commentMode, cliArgs = true, cliArgs[1:]
+	}
+
 	var (
 		fontPath       string
+		fontArchive    string
+		controlPaths   []string
 		unknownRune    string
 		showVersion    bool
 		showHelp       bool
@@ -36,12 +53,35 @@ func run() int {
 		fullWidth      bool
 		smushMode      bool
 		kernMode       bool
+		vertical       string
+		justifyLeft    bool
+		justifyCenter  bool
+		justifyRight   bool
+		justifyAuto    bool
+		paragraph      bool
+		wrap           string
+		hyphenate      bool
+		balanced       bool
+		fit            bool
+		color          string
+		gradient       string
+		gradientDir    string
+		format         string
+		htmlClass      string
+		outputPath     string
+		pixels         string
+		lang           string
+		commentStyle   string
+		box            int
+		normal         bool
 		debugMode      bool
 		debugFile      string
 		debugPretty    bool
 	)
 
-	pflag.StringVarP(&fontPath, "font", "f", "standard", "Path to FIGfont file or font name")
+	pflag.StringVarP(&fontPath, "font", "f", "standard", "Path to FIGfont (.flf) or TOIlet (.tlf) font file, or font name; with --fit, a comma-separated list")
+	pflag.StringVar(&fontArchive, "font-archive", "", "ZIP archive of fonts; -f and -C name entries in it")
+	pflag.StringArrayVarP(&controlPaths, "control", "C", nil, "Path to FIGlet control file (.flc) or name; repeat to chain")
 	pflag.StringVarP(&unknownRune, "unknown-rune", "u", "?", "Rune to replace unknown/unsupported characters")
 	pflag.BoolVarP(&showVersion, "version", "v", false, "Show version information")
 	pflag.BoolVarP(&showHelp, "help", "h", false, "Show help message")
@@ -50,10 +90,31 @@ func run() int {
 	pflag.BoolVarP(&fullWidth, "full-width", "W", false, "Use full-width mode (no kerning or smushing)")
 	pflag.BoolVarP(&smushMode, "smush", "s", false, "Use smushing mode (characters overlap)")
 	pflag.BoolVarP(&kernMode, "kern", "k", false, "Use kerning mode (characters touch but don't overlap)")
+	pflag.StringVar(&vertical, "vertical", "full", "Stacking of output lines: full, fit, smush or font (the font's own layout)")
+	pflag.BoolVarP(&justifyLeft, "left", "l", false, "Left-justify each line (default)")
+	pflag.BoolVarP(&justifyCenter, "center", "c", false, "Centre each line within the width")
+	pflag.BoolVarP(&justifyRight, "right", "r", false, "Right-justify each line within the width")
+	pflag.BoolVarP(&justifyAuto, "auto-justify", "x", false, "Right-justify right-to-left fonts, left-justify others")
+	pflag.BoolVarP(&paragraph, "paragraph", "p", false, "Paragraph mode: join lines, breaking only at blank or indented lines")
+	pflag.BoolVarP(&normal, "normal", "n", false, "Normal mode: every newline breaks the line (default; overrides -p)")
+	pflag.StringVar(&wrap, "wrap", "word-char", "Line breaking at the width: word-char, word or char")
+	pflag.BoolVar(&hyphenate, "hyphenate", false, "Hyphenate words broken across lines")
+	pflag.BoolVar(&balanced, "balanced", false, "Balance line lengths when wrapping instead of filling each line")
+	pflag.BoolVar(&fit, "fit", false, "Use the first -f font and loosest layout that fit the width without wrapping")
+	pflag.StringVar(&color, "color", "", "Colour each character: a colour (name or #rrggbb), a comma-separated list to cycle, or rainbow")
+	pflag.StringVar(&gradient, "gradient", "", "Colour the output with a gradient between two colours, FROM:TO")
+	pflag.StringVar(&gradientDir, "gradient-dir", "horizontal", "Direction of --gradient: horizontal or vertical")
+	pflag.StringVar(&pixels, "pixels", "off", "Pack output into pixels: off, half (half blocks) or braille")
+	pflag.StringVar(&lang, "lang", "", "Wrap the output in the comment syntax of a language (go, python, sql, ...)")
+	pflag.StringVar(&commentStyle, "comment-style", "", "Wrap the output in a comment: slashes, hash, dashes, semi, rem, block or html")
+	pflag.IntVar(&box, "box", -1, "With a comment, pad lines to this total width (0: the widest line) and close them")
+	pflag.StringVar(&format, "format", "text", "Output format: text, html, svg or png")
+	pflag.StringVarP(&outputPath, "output", "o", "", "Write the output to a file instead of standard output")
+	pflag.StringVar(&htmlClass, "html-class", "", "With --format html, class name prefix for the wrapper and each character")
 	pflag.BoolVar(&debugMode, "debug", false, "Enable debug mode (outputs to stderr)")
 	pflag.StringVar(&debugFile, "debug-file", "", "Write debug output to file instead of stderr")
 	pflag.BoolVar(&debugPretty, "debug-pretty", false, "Use pretty format for debug output (default: JSON)")
-	pflag.Parse()
+	_ = pflag.CommandLine.Parse(cliArgs) // exits on error
 
 	if showHelp {
 		printHelp()
@@ -72,6 +133,11 @@ func run() int {
 		return 1
 	}
 
+	if format != "text" && format != "html" && format != "svg" && format != "png" {
+		fmt.Fprintf(os.Stderr, "Error: unknown format %q (want text, html, svg or png)\n", format)
+		return 1
+	}
+
 	// Parse unknown rune option
 	var unknownRuneValue = '?'
 	if unknownRune != "" {
@@ -83,20 +149,33 @@ func run() int {
 		unknownRuneValue = parsed
 	}
 
-	// Resolve font path
-	resolvedPath := resolveFontPath(fontPath)
+	// Open the font archive, if any, to pick the font and control files from
+	var pack *figgo.FontPack
+	if fontArchive != "" {
+		var err error
+		pack, err = figgo.OpenFontArchive(fontArchive)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error opening font archive: %v\n", err)
+			return 1
+		}
+	}
 
-	// Load font
-	fontFile, err := os.Open(resolvedPath)
+	// With --fit, -f lists the candidate fonts in order of preference
+	fontPaths := []string{fontPath}
+	if fit {
+		fontPaths = splitFontList(fontPath)
+	}
+	fonts, err := loadFonts(fontPaths, pack)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error opening font file: %v\n", err)
+		fmt.Fprintf(os.Stderr, "Error loading font: %v\n", err)
 		return 1
 	}
-	defer fontFile.Close()
+	font := fonts[0]
 
-	font, err := figgo.ParseFont(fontFile)
+	// Load control files in the order given
+	controlFiles, err := loadControlFiles(controlPaths, pack)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error parsing font: %v\n", err)
+		fmt.Fprintf(os.Stderr, "Error loading control file: %v\n", err)
 		return 1
 	}
 
@@ -119,6 +198,10 @@ func run() int {
 		figgo.WithWidth(width),
 	}
 
+	for _, cf := range controlFiles {
+		renderOpts = append(renderOpts, figgo.WithControlFile(cf))
+	}
+
 	// Add debug if enabled
 	if debugSession != nil {
 		renderOpts = append(renderOpts, figgo.WithDebug(debugSession))
@@ -126,6 +209,12 @@ func run() int {
 	if trimWhitespace {
 		renderOpts = append(renderOpts, figgo.WithTrimWhitespace(true))
 	}
+	if balanced {
+		renderOpts = append(renderOpts, figgo.WithBalancedWrap(true))
+	}
+	if paragraph && !normal {
+		renderOpts = append(renderOpts, figgo.WithParagraphMode(true))
+	}
 	// Layout mode flags are mutually exclusive
 	switch {
 	case fullWidth:
@@ -138,16 +227,285 @@ func run() int {
 		// This matches figlet's behavior when -s is specified (no override)
 	}
 
-	output, err := figgo.Render(text, font, renderOpts...)
+	wrapMode, err := parseWrapMode(wrap)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	renderOpts = append(renderOpts, figgo.WithWrapMode(wrapMode), figgo.WithHyphenation(hyphenate))
+
+	pixelMode, err := parsePixelMode(pixels)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	renderOpts = append(renderOpts, figgo.WithPixelMode(pixelMode))
+
+	commentOpts, err := commentOptions(commentMode, lang, commentStyle, box)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	if len(commentOpts) > 0 && format != "text" {
+		fmt.Fprintf(os.Stderr, "Error: comments need --format text, not %q\n", format)
+		return 1
+	}
+	renderOpts = append(renderOpts, commentOpts...)
+
+	verticalOpt, err := verticalLayoutOption(vertical, font)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	renderOpts = append(renderOpts, verticalOpt)
+	renderOpts = append(renderOpts, figgo.WithJustify(justifyFromFlags(justifyCenter, justifyRight, justifyAuto)))
+
+	styleOpts, err := styleOptions(color, gradient, gradientDir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	renderOpts = append(renderOpts, styleOpts...)
+	if len(styleOpts) > 0 && format == "text" {
+		// NO_COLOR and COLORTERM describe the terminal; HTML uses CSS
+		renderOpts = append(renderOpts, figgo.WithColorMode(colorModeFromEnv(os.Getenv)))
+	}
+	if htmlClass != "" {
+		renderOpts = append(renderOpts, figgo.WithHTMLClasses(htmlClass))
+	}
+
+	var output string
+	if fit {
+		// The layout flags are ignored; --fit picks the layout. When nothing
+		// fits, the last font is still printed, wrapped
+		var res figgo.FitResult
+		res, err = figgo.RenderFit(text, width, fonts, renderOpts...)
+		if errors.Is(err, figgo.ErrNoFit) {
+			fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+			err = nil
+		}
+		output = res.Output
+		if err == nil {
+			// Other formats render the chosen font and layout
+			font = res.Font
+			renderOpts = append(renderOpts, figgo.WithLayout(res.Layout))
+		}
+	} else if format == "text" {
+		output, err = figgo.Render(text, font, renderOpts...)
+	}
+	var img image.Image
+	if err == nil && format != "text" {
+		var sb strings.Builder
+		switch format {
+		case "html":
+			err = figgo.RenderHTML(&sb, text, font, renderOpts...)
+		case "svg":
+			err = figgo.RenderSVG(&sb, text, font, renderOpts...)
+		case "png":
+			img, err = figgo.RenderImage(text, font, renderOpts...)
+		}
+		output = strings.TrimSuffix(sb.String(), "\n")
+	}
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error rendering text: %v\n", err)
 		return 1
 	}
 
-	fmt.Println(output)
+	err = writeOutput(outputPath, func(w io.Writer) error {
+		if img != nil {
+			return png.Encode(w, img)
+		}
+		_, err := fmt.Fprintln(w, output)
+		return err
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error writing output: %v\n", err)
+		return 1
+	}
 	return 0
 }
 
+// writeOutput calls write with the file at path, created or truncated, or
+// with standard output when path is empty.
+func writeOutput(path string, write func(io.Writer) error) error {
+	if path == "" {
+		return write(os.Stdout)
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := write(f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// verticalLayoutOption maps the --vertical flag to a render option. "smush"
+// uses the font's vertical rules, falling back to universal smushing.
+func verticalLayoutOption(name string, font *figgo.Font) (figgo.Option, error) {
+	fontLayout := font.NormalizedLayout()
+	switch name {
+	case "full":
+		return figgo.WithVerticalLayout(figgo.ModeFull, 0), nil
+	case "fit":
+		return figgo.WithVerticalLayout(figgo.ModeFitting, 0), nil
+	case "smush":
+		return figgo.WithVerticalLayout(figgo.ModeSmushingControlled, fontLayout.VertRules), nil
+	case "font":
+		return figgo.WithVerticalLayout(fontLayout.VertMode, fontLayout.VertRules), nil
+	default:
+		return nil, fmt.Errorf("unknown vertical layout %q (want full, fit, smush or font)", name)
+	}
+}
+
+// parseWrapMode maps the --wrap flag to a wrap mode.
+func parseWrapMode(name string) (figgo.WrapMode, error) {
+	switch name {
+	case "word-char":
+		return figgo.WrapWordThenChar, nil
+	case "word":
+		return figgo.WrapWord, nil
+	case "char":
+		return figgo.WrapChar, nil
+	default:
+		return 0, fmt.Errorf("unknown wrap mode %q (want word-char, word or char)", name)
+	}
+}
+
+// commentStyles maps the --comment-style flag to comment styles.
+var commentStyles = map[string]figgo.CommentStyle{
+	"slashes": figgo.CommentSlashes,
+	"hash":    figgo.CommentHash,
+	"dashes":  figgo.CommentDashes,
+	"semi":    figgo.CommentSemi,
+	"rem":     figgo.CommentREM,
+	"block":   figgo.CommentBlock,
+	"html":    figgo.CommentHTML,
+}
+
+// commentOptions maps "figgo comment" and the --lang, --comment-style and
+// --box flags to comment options. Any of the flags turns comments on;
+// --comment-style wins over --lang, and the default is "//".
+func commentOptions(commentMode bool, lang, styleName string, box int) ([]figgo.Option, error) {
+	if !commentMode && lang == "" && styleName == "" && box < 0 {
+		return nil, nil
+	}
+
+	style := figgo.CommentSlashes
+	switch {
+	case styleName != "":
+		var ok bool
+		if style, ok = commentStyles[styleName]; !ok {
+			return nil, fmt.Errorf("unknown comment style %q (want slashes, hash, dashes, semi, rem, block or html)", styleName)
+		}
+	case lang != "":
+		var ok bool
+		if style, ok = figgo.CommentStyleFor(lang); !ok {
+			return nil, fmt.Errorf("unknown language %q for comments (try --comment-style)", lang)
+		}
+	}
+
+	opts := []figgo.Option{figgo.WithCommentStyle(style)}
+	if box >= 0 {
+		opts = append(opts, figgo.WithCommentBox(box))
+	}
+	return opts, nil
+}
+
+// parsePixelMode maps the --pixels flag to a pixel mode.
+func parsePixelMode(name string) (figgo.PixelMode, error) {
+	switch name {
+	case "off":
+		return figgo.PixelOff, nil
+	case "half":
+		return figgo.PixelHalfBlock, nil
+	case "braille":
+		return figgo.PixelBraille, nil
+	default:
+		return 0, fmt.Errorf("unknown pixel mode %q (want off, half or braille)", name)
+	}
+}
+
+// styleOptions maps the --color, --gradient and --gradient-dir flags to
+// style options. --color takes a colour, a comma-separated list of colours
+// to cycle through character by character, or "rainbow".
+func styleOptions(color, gradient, dir string) ([]figgo.Option, error) {
+	var opts []figgo.Option
+	switch {
+	case color == "":
+	case strings.EqualFold(color, "rainbow"):
+		opts = append(opts, figgo.WithRainbow())
+	default:
+		var styles []figgo.Style
+		for _, name := range strings.Split(color, ",") {
+			c, err := figgo.ParseColor(name)
+			if err != nil {
+				return nil, err
+			}
+			styles = append(styles, figgo.Style{Fg: c})
+		}
+		opts = append(opts, figgo.WithGlyphStyles(styles...))
+	}
+
+	if gradient != "" {
+		fromName, toName, ok := strings.Cut(gradient, ":")
+		if !ok {
+			return nil, fmt.Errorf("invalid gradient %q (want FROM:TO)", gradient)
+		}
+		from, err := figgo.ParseColor(fromName)
+		if err != nil {
+			return nil, err
+		}
+		to, err := figgo.ParseColor(toName)
+		if err != nil {
+			return nil, err
+		}
+		var direction figgo.GradientDirection
+		switch dir {
+		case "horizontal":
+			direction = figgo.GradientHorizontal
+		case "vertical":
+			direction = figgo.GradientVertical
+		default:
+			return nil, fmt.Errorf("unknown gradient direction %q (want horizontal or vertical)", dir)
+		}
+		opts = append(opts, figgo.WithGradient(from, to, direction))
+	}
+	return opts, nil
+}
+
+// colorModeFromEnv picks the colour mode from the environment: none when
+// NO_COLOR is set (see no-color.org), truecolor when COLORTERM says the
+// terminal supports it, and 256 colours otherwise.
+func colorModeFromEnv(getenv func(string) string) figgo.ColorMode {
+	if getenv("NO_COLOR") != "" {
+		return figgo.ColorNone
+	}
+	switch getenv("COLORTERM") {
+	case "truecolor", "24bit":
+		return figgo.ColorTrue
+	}
+	return figgo.Color256
+}
+
+// justifyFromFlags maps the -c, -r and -x flags to a justification; -l is
+// the default. Like the layout flags they are mutually exclusive; if several
+// are given, -c wins over -r and -r over -x.
+func justifyFromFlags(center, right, auto bool) figgo.Justify {
+	switch {
+	case center:
+		return figgo.JustifyCenter
+	case right:
+		return figgo.JustifyRight
+	case auto:
+		return figgo.JustifyAuto
+	default:
+		return figgo.JustifyLeft
+	}
+}
+
 // setupDebug initializes the debug system and returns the session, a cleanup function, and any error.
 func setupDebug(debugMode bool, debugFile string, debugPretty bool) (interface{}, func(), error) {
 	if !debugMode && debugFile == "" && os.Getenv("FIGGO_DEBUG") != "1" {
@@ -282,32 +640,115 @@ func parseDecimal(s string) (rune, bool) {
 	return 0, false
 }
 
-// resolveFontPath resolves a font path from either a full path or just a font name
+// resolveFontPath resolves a font path from either a full path or just a font name.
+// FIGfonts (.flf) are preferred over TOIlet fonts (.tlf) with the same name.
 func resolveFontPath(fontPath string) string {
-	// If it's already a full path to a .flf file, use it directly
-	if filepath.Ext(fontPath) == ".flf" {
-		return fontPath
+	return resolvePath(fontPath, ".flf", ".tlf")
+}
+
+// resolveControlPath resolves a control file path from either a full path or just a name
+func resolveControlPath(controlPath string) string {
+	return resolvePath(controlPath, ".flc")
+}
+
+// resolvePath resolves a file given as a full path or a bare name, trying each
+// extension and then the fonts/ directory in turn.
+func resolvePath(name string, exts ...string) string {
+	// If it's already a full path with a known extension, use it directly
+	for _, ext := range exts {
+		if filepath.Ext(name) == ext {
+			return name
+		}
 	}
 
 	// Check if it exists as is
-	if _, err := os.Stat(fontPath); err == nil {
-		return fontPath
+	if _, err := os.Stat(name); err == nil {
+		return name
 	}
 
-	// Try adding .flf extension
-	withExt := fontPath + ".flf"
-	if _, err := os.Stat(withExt); err == nil {
-		return withExt
+	// Try adding each extension
+	for _, ext := range exts {
+		withExt := name + ext
+		if _, err := os.Stat(withExt); err == nil {
+			return withExt
+		}
 	}
 
 	// Try in fonts/ directory
-	inFonts := filepath.Join("fonts", fontPath+".flf")
-	if _, err := os.Stat(inFonts); err == nil {
-		return inFonts
+	for _, ext := range exts {
+		inFonts := filepath.Join("fonts", name+ext)
+		if _, err := os.Stat(inFonts); err == nil {
+			return inFonts
+		}
 	}
 
 	// Default to original path (will fail with better error later)
-	return fontPath
+	return name
+}
+
+// loadFont loads the font named by fontPath, from the archive when one is
+// given and otherwise from the filesystem.
+func loadFont(fontPath string, pack *figgo.FontPack) (*figgo.Font, error) {
+	if pack != nil {
+		return pack.LoadFont(fontPath)
+	}
+
+	fontFile, err := os.Open(resolveFontPath(fontPath))
+	if err != nil {
+		return nil, err
+	}
+	defer fontFile.Close()
+
+	return figgo.ParseFont(fontFile)
+}
+
+// loadFonts loads each named font in order, from the archive when one is
+// given and otherwise from the filesystem.
+func loadFonts(paths []string, pack *figgo.FontPack) ([]*figgo.Font, error) {
+	if len(paths) == 0 {
+		return nil, errors.New("no font given")
+	}
+	fonts := make([]*figgo.Font, 0, len(paths))
+	for _, p := range paths {
+		font, err := loadFont(p, pack)
+		if err != nil {
+			return nil, err
+		}
+		fonts = append(fonts, font)
+	}
+	return fonts, nil
+}
+
+// splitFontList splits a comma-separated -f value into font names, dropping
+// empty entries.
+func splitFontList(list string) []string {
+	var names []string
+	for _, name := range strings.Split(list, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// loadControlFiles loads each control file in order, from the archive when
+// one is given and otherwise from the filesystem.
+func loadControlFiles(paths []string, pack *figgo.FontPack) ([]*figgo.ControlFile, error) {
+	controlFiles := make([]*figgo.ControlFile, 0, len(paths))
+	for _, p := range paths {
+		var cf *figgo.ControlFile
+		var err error
+		if pack != nil {
+			cf, err = pack.LoadControlFile(p)
+		} else {
+			cf, err = figgo.LoadControlFile(resolveControlPath(p))
+		}
+		if err != nil {
+			return nil, err
+		}
+		controlFiles = append(controlFiles, cf)
+	}
+	return controlFiles, nil
 }
 
 func printHelp() {
@@ -315,6 +756,8 @@ func printHelp() {
 	fmt.Println()
 	fmt.Println("Usage:")
 	fmt.Println("  figgo [flags] <text>")
+	fmt.Println("  figgo lint [flags] <file>...   Check font and control files")
+	fmt.Println("  figgo comment [flags] <text>   Render a banner as a source code comment (see --lang)")
 	fmt.Println()
 	fmt.Println("Flags:")
 	pflag.PrintDefaults()
@@ -1,13 +1,21 @@
 package main
 
 import (
+	"archive/zip"
 	"bytes"
 	"context"
+	"errors"
+	"io"
+	"os"
 	"os/exec"
 	"path/filepath"
+	"reflect"
 	"runtime"
+	"strings"
 	"sync"
 	"testing"
+
+	"github.com/ryanlewis/figgo"
 )
 
 func TestParseUnknownRune(t *testing.T) {
@@ -187,3 +195,324 @@ func TestCLIConcurrentSubprocesses(t *testing.T) {
 		t.Errorf("expected results for %d inputs, got %d", len(inputs), len(outputsByInput))
 	}
 }
+
+func TestResolveFontPath(t *testing.T) {
+	dir := t.TempDir()
+	t.Chdir(dir)
+
+	for _, name := range []string{"fonts/standard.flf", "fonts/future.tlf", "fonts/both.flf", "fonts/both.tlf", "local.tlf"} {
+		if err := os.MkdirAll(filepath.Dir(name), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(name, nil, 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"standard", filepath.Join("fonts", "standard.flf")},
+		{"future", filepath.Join("fonts", "future.tlf")},
+		{"both", filepath.Join("fonts", "both.flf")},
+		{"local", "local.tlf"},
+		{"explicit.tlf", "explicit.tlf"},
+		{"missing", "missing"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if got := resolveFontPath(tt.input); got != tt.want {
+				t.Errorf("resolveFontPath(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadFromFontArchive(t *testing.T) {
+	standard, err := os.ReadFile(filepath.Join(projectRoot(), "fonts", "standard.flf"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, content := range map[string][]byte{
+		"fonts/standard.flf": standard,
+		"upper.flc":          []byte("flc2a\nt a-z A-Z\n"),
+	} {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.Write(content); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	archive := filepath.Join(t.TempDir(), "pack.zip")
+	if err := os.WriteFile(archive, buf.Bytes(), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	pack, err := figgo.OpenFontArchive(archive)
+	if err != nil {
+		t.Fatalf("OpenFontArchive() error = %v", err)
+	}
+
+	font, err := loadFont("standard", pack)
+	if err != nil {
+		t.Fatalf("loadFont() error = %v", err)
+	}
+	if font.Name != "standard" {
+		t.Errorf("font.Name = %q, want %q", font.Name, "standard")
+	}
+
+	controlFiles, err := loadControlFiles([]string{"upper"}, pack)
+	if err != nil {
+		t.Fatalf("loadControlFiles() error = %v", err)
+	}
+	if len(controlFiles) != 1 || controlFiles[0].Name != "upper" {
+		t.Errorf("loadControlFiles() = %v, want the upper control file", controlFiles)
+	}
+
+	if _, err := loadFont("slant", pack); err == nil {
+		t.Error("loadFont() expected error for a font missing from the archive")
+	}
+}
+
+func TestVerticalLayoutOption(t *testing.T) {
+	font, err := figgo.LoadFont(filepath.Join(projectRoot(), "fonts", "standard.flf"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	full, err := figgo.Render("a\nb", font)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, name := range []string{"full", "fit", "smush", "font"} {
+		t.Run(name, func(t *testing.T) {
+			opt, err := verticalLayoutOption(name, font)
+			if err != nil {
+				t.Fatalf("verticalLayoutOption(%q) error = %v", name, err)
+			}
+			got, err := figgo.Render("a\nb", font, opt)
+			if err != nil {
+				t.Fatalf("Render() error = %v", err)
+			}
+			if (got == full) != (name == "full") {
+				t.Errorf("--vertical %s output:\n%s", name, got)
+			}
+		})
+	}
+
+	if _, err := verticalLayoutOption("tight", font); err == nil {
+		t.Error("verticalLayoutOption() expected error for an unknown layout")
+	}
+}
+
+func TestJustifyFromFlags(t *testing.T) {
+	tests := []struct {
+		name                string
+		center, right, auto bool
+		want                figgo.Justify
+	}{
+		{name: "default", want: figgo.JustifyLeft},
+		{name: "center", center: true, want: figgo.JustifyCenter},
+		{name: "right", right: true, want: figgo.JustifyRight},
+		{name: "auto", auto: true, want: figgo.JustifyAuto},
+		{name: "center wins", center: true, right: true, auto: true, want: figgo.JustifyCenter},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := justifyFromFlags(tt.center, tt.right, tt.auto); got != tt.want {
+				t.Errorf("justifyFromFlags() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseWrapMode(t *testing.T) {
+	tests := map[string]figgo.WrapMode{
+		"word-char": figgo.WrapWordThenChar,
+		"word":      figgo.WrapWord,
+		"char":      figgo.WrapChar,
+	}
+	for name, want := range tests {
+		got, err := parseWrapMode(name)
+		if err != nil || got != want {
+			t.Errorf("parseWrapMode(%q) = %v, %v, want %v", name, got, err, want)
+		}
+	}
+	if _, err := parseWrapMode("line"); err == nil {
+		t.Error("parseWrapMode() expected error for an unknown mode")
+	}
+}
+
+func TestSplitFontList(t *testing.T) {
+	tests := map[string][]string{
+		"standard":           {"standard"},
+		"big,standard,small": {"big", "standard", "small"},
+		" big , small ,":     {"big", "small"},
+		",,":                 nil,
+	}
+	for list, want := range tests {
+		if got := splitFontList(list); !reflect.DeepEqual(got, want) {
+			t.Errorf("splitFontList(%q) = %q, want %q", list, got, want)
+		}
+	}
+}
+
+func TestStyleOptions(t *testing.T) {
+	tests := []struct {
+		name                 string
+		color, gradient, dir string
+		wantOpts             int
+		wantErr              bool
+	}{
+		{name: "none", dir: "horizontal"},
+		{name: "one colour", color: "red", dir: "horizontal", wantOpts: 1},
+		{name: "colour list", color: "red,#00ff00,bright-blue", dir: "horizontal", wantOpts: 1},
+		{name: "rainbow", color: "Rainbow", dir: "horizontal", wantOpts: 1},
+		{name: "gradient", gradient: "red:blue", dir: "vertical", wantOpts: 1},
+		{name: "both", color: "red", gradient: "#000:#fff", dir: "horizontal", wantOpts: 2},
+		{name: "bad colour", color: "red,mauve", dir: "horizontal", wantErr: true},
+		{name: "bad gradient", gradient: "red", dir: "horizontal", wantErr: true},
+		{name: "bad gradient colour", gradient: "red:nope", dir: "horizontal", wantErr: true},
+		{name: "bad direction", gradient: "red:blue", dir: "diagonal", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts, err := styleOptions(tt.color, tt.gradient, tt.dir)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("styleOptions() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if len(opts) != tt.wantOpts {
+				t.Errorf("styleOptions() returned %d options, want %d", len(opts), tt.wantOpts)
+			}
+		})
+	}
+}
+
+func TestColorModeFromEnv(t *testing.T) {
+	tests := []struct {
+		name string
+		env  map[string]string
+		want figgo.ColorMode
+	}{
+		{name: "default", want: figgo.Color256},
+		{name: "truecolor", env: map[string]string{"COLORTERM": "truecolor"}, want: figgo.ColorTrue},
+		{name: "24bit", env: map[string]string{"COLORTERM": "24bit"}, want: figgo.ColorTrue},
+		{name: "NO_COLOR", env: map[string]string{"NO_COLOR": "1", "COLORTERM": "truecolor"}, want: figgo.ColorNone},
+		{name: "empty NO_COLOR", env: map[string]string{"NO_COLOR": ""}, want: figgo.Color256},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			getenv := func(key string) string { return tt.env[key] }
+			if got := colorModeFromEnv(getenv); got != tt.want {
+				t.Errorf("colorModeFromEnv() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWriteOutput(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "out.txt")
+	if err := os.WriteFile(path, []byte("old contents"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	err := writeOutput(path, func(w io.Writer) error {
+		_, err := io.WriteString(w, "banner\n")
+		return err
+	})
+	if err != nil {
+		t.Fatalf("writeOutput() error = %v", err)
+	}
+	if got, _ := os.ReadFile(path); string(got) != "banner\n" {
+		t.Errorf("file contents = %q, want %q", got, "banner\n")
+	}
+
+	failed := errors.New("write failed")
+	if err := writeOutput(path, func(io.Writer) error { return failed }); err != failed {
+		t.Errorf("writeOutput() error = %v, want %v", err, failed)
+	}
+	if err := writeOutput(filepath.Join(path, "nested"), func(io.Writer) error { return nil }); err == nil {
+		t.Error("writeOutput() expected error for a path that cannot be created")
+	}
+}
+
+func TestParsePixelMode(t *testing.T) {
+	tests := map[string]figgo.PixelMode{
+		"off":     figgo.PixelOff,
+		"half":    figgo.PixelHalfBlock,
+		"braille": figgo.PixelBraille,
+	}
+	for name, want := range tests {
+		got, err := parsePixelMode(name)
+		if err != nil || got != want {
+			t.Errorf("parsePixelMode(%q) = %v, %v, want %v", name, got, err, want)
+		}
+	}
+	if _, err := parsePixelMode("sixel"); err == nil {
+		t.Error("parsePixelMode() expected error for an unknown mode")
+	}
+}
+
+func TestCommentOptions(t *testing.T) {
+	font, err := figgo.LoadFont(filepath.Join(projectRoot(), "fonts", "standard.flf"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name        string
+		commentMode bool
+		lang, style string
+		box         int
+		wantPrefix  string // of the rendered output; "" for no comment
+		wantErr     bool
+	}{
+		{name: "off", box: -1},
+		{name: "subcommand default", commentMode: true, box: -1, wantPrefix: "// "},
+		{name: "lang", lang: "python", box: -1, wantPrefix: "# "},
+		{name: "style wins", lang: "python", style: "dashes", box: -1, wantPrefix: "-- "},
+		{name: "block", commentMode: true, lang: "css", box: -1, wantPrefix: "/*\n * "},
+		{name: "box alone", box: 0, wantPrefix: "// "},
+		{name: "unknown lang", lang: "cobol-2060", box: -1, wantErr: true},
+		{name: "unknown style", style: "pipes", box: -1, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts, err := commentOptions(tt.commentMode, tt.lang, tt.style, tt.box)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("commentOptions() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			got, err := figgo.Render("Hi", font, opts...)
+			if err != nil {
+				t.Fatalf("Render() error = %v", err)
+			}
+			if tt.wantPrefix == "" {
+				if len(opts) != 0 {
+					t.Errorf("commentOptions() = %d options, want none", len(opts))
+				}
+				return
+			}
+			if !strings.HasPrefix(got, tt.wantPrefix) {
+				t.Errorf("output = %q, want prefix %q", got, tt.wantPrefix)
+			}
+		})
+	}
+}
@@ -0,0 +1,171 @@
+package figgo
+
+import (
+	"io"
+	"strings"
+	"unicode/utf8"
+)
+
+// CommentStyle is the comment syntax of a programming language.
+type CommentStyle struct {
+	// Line starts each line of the comment, e.g. "//". Inside a block
+	// comment it is optional, e.g. " *".
+	Line string
+	// Open and Close delimit a block comment, e.g. "/*" and " */"; both are
+	// empty for line comments.
+	Open, Close string
+}
+
+// Comment styles
+var (
+	CommentSlashes = CommentStyle{Line: "//"}                           // C++, Go, Rust, JavaScript, ...
+	CommentHash    = CommentStyle{Line: "#"}                            // Python, shell, Ruby, YAML, ...
+	CommentDashes  = CommentStyle{Line: "--"}                           // SQL, Lua, Haskell
+	CommentSemi    = CommentStyle{Line: ";;"}                           // Lisp, Clojure, Scheme
+	CommentREM     = CommentStyle{Line: "REM"}                          // Windows batch files
+	CommentBlock   = CommentStyle{Line: " *", Open: "/*", Close: " */"} // C, CSS
+	CommentHTML    = CommentStyle{Open: "<!--", Close: "-->"}           // HTML, XML, Markdown
+)
+
+// commentLanguages maps language names and file extensions to their
+// comment styles.
+var commentLanguages = map[string]CommentStyle{
+	"go": CommentSlashes, "c++": CommentSlashes, "cpp": CommentSlashes, "java": CommentSlashes,
+	"javascript": CommentSlashes, "js": CommentSlashes, "typescript": CommentSlashes, "ts": CommentSlashes,
+	"rust": CommentSlashes, "rs": CommentSlashes, "swift": CommentSlashes, "kotlin": CommentSlashes,
+	"kt": CommentSlashes, "csharp": CommentSlashes, "cs": CommentSlashes, "scala": CommentSlashes,
+	"dart": CommentSlashes, "php": CommentSlashes, "zig": CommentSlashes,
+
+	"python": CommentHash, "py": CommentHash, "ruby": CommentHash, "rb": CommentHash,
+	"shell": CommentHash, "sh": CommentHash, "bash": CommentHash, "zsh": CommentHash,
+	"perl": CommentHash, "pl": CommentHash, "r": CommentHash, "yaml": CommentHash, "yml": CommentHash,
+	"toml": CommentHash, "make": CommentHash, "makefile": CommentHash, "dockerfile": CommentHash,
+	"powershell": CommentHash, "ps1": CommentHash, "elixir": CommentHash, "ex": CommentHash,
+	"nim": CommentHash, "tcl": CommentHash,
+
+	"sql": CommentDashes, "lua": CommentDashes, "haskell": CommentDashes, "hs": CommentDashes,
+	"ada": CommentDashes, "elm": CommentDashes,
+
+	"lisp": CommentSemi, "clojure": CommentSemi, "clj": CommentSemi, "scheme": CommentSemi,
+	"elisp": CommentSemi, "el": CommentSemi, "racket": CommentSemi,
+
+	"batch": CommentREM, "bat": CommentREM, "cmd": CommentREM,
+
+	"c": CommentBlock, "h": CommentBlock, "css": CommentBlock,
+
+	"html": CommentHTML, "htm": CommentHTML, "xml": CommentHTML, "svg": CommentHTML,
+	"markdown": CommentHTML, "md": CommentHTML, "vue": CommentHTML,
+}
+
+// CommentStyleFor returns the comment style of a language, given by name
+// or file extension ("go", "python", "sql", "md", ...), and false for a
+// language it does not know. Names are case-insensitive.
+func CommentStyleFor(lang string) (CommentStyle, bool) {
+	style, ok := commentLanguages[strings.ToLower(strings.TrimPrefix(lang, "."))]
+	return style, ok
+}
+
+// WithCommentStyle wraps the output of Render and RenderTo in a comment,
+// for pasting banners into source files as section headers. Trailing
+// whitespace is trimmed, and in block comments any sequence that would end
+// the comment early (such as "*/" made by smushing) is broken up by
+// replacing its first character with a space. The comment prefix counts
+// against the width given to WithWidth. Style options are ignored.
+//
+// Example:
+//
+//	style, _ := figgo.CommentStyleFor("go")
+//	banner, err := figgo.Render("Parser", font, figgo.WithCommentStyle(style))
+func WithCommentStyle(style CommentStyle) Option {
+	return func(opts *options) {
+		opts.comment = &style
+	}
+}
+
+// WithCommentBox pads each line of a comment made with WithCommentStyle to
+// the given total width, or to its widest line when width is 0, and closes
+// it with the line marker to form a box. Without WithWidth, lines also wrap
+// to fit the box.
+func WithCommentBox(width int) Option {
+	return func(opts *options) {
+		width = max(width, 0)
+		opts.commentBox = &width
+	}
+}
+
+// prefix returns what starts each line of art.
+func (c CommentStyle) prefix() string {
+	if c.Line == "" {
+		return ""
+	}
+	return c.Line + " "
+}
+
+// boxSuffix returns what closes each line of art in a box.
+func (c CommentStyle) boxSuffix() string {
+	if marker := strings.TrimSpace(c.Line); marker != "" {
+		return " " + marker
+	}
+	return ""
+}
+
+// writeComment renders text and writes it wrapped in the options' comment
+// style.
+func writeComment(w io.Writer, text string, f *Font, options *options) error {
+	style := *options.comment
+	overhead := utf8.RuneCountInString(style.prefix())
+	if options.commentBox != nil {
+		overhead += utf8.RuneCountInString(style.boxSuffix())
+	}
+
+	inner := *options
+	inner.comment, inner.stylers = nil, nil
+	width := inner.width
+	if width == nil && options.commentBox != nil && *options.commentBox > 0 {
+		width = options.commentBox
+	}
+	if width != nil {
+		artWidth := max(*width-overhead, 1)
+		inner.width = &artWidth
+	}
+
+	var sb strings.Builder
+	if err := renderTo(&sb, text, f, &inner); err != nil {
+		return err
+	}
+	lines := strings.Split(sb.String(), "\n")
+
+	closer := strings.TrimSpace(style.Close)
+	artWidth := 0
+	for i, line := range lines {
+		line = strings.TrimRight(line, " ")
+		for closer != "" && strings.Contains(line, closer) {
+			at := strings.Index(line, closer)
+			_, size := utf8.DecodeRuneInString(line[at:])
+			line = line[:at] + " " + line[at+size:]
+		}
+		lines[i] = line
+		artWidth = max(artWidth, utf8.RuneCountInString(line))
+	}
+	if options.commentBox != nil && *options.commentBox > 0 {
+		artWidth = max(artWidth, *options.commentBox-overhead)
+	}
+
+	var buf strings.Builder
+	if style.Open != "" {
+		buf.WriteString(style.Open + "\n")
+	}
+	for _, line := range lines {
+		out := style.prefix() + line
+		if options.commentBox != nil {
+			out += strings.Repeat(" ", artWidth-utf8.RuneCountInString(line)) + style.boxSuffix()
+		}
+		buf.WriteString(strings.TrimRight(out, " "))
+		buf.WriteByte('\n')
+	}
+	if style.Close != "" {
+		buf.WriteString(style.Close + "\n")
+	}
+	_, err := io.WriteString(w, strings.TrimSuffix(buf.String(), "\n"))
+	return err
+}
@@ -0,0 +1,112 @@
+package figgo
+
+import (
+	"strings"
+	"testing"
+)
+
+// commentFont builds a one-row font where each glyph is the character
+// itself, so tests can spell out comment delimiters.
+func commentFont(t *testing.T) *Font {
+	t.Helper()
+	b := NewFontBuilder(1, 1, '$').SetGlyph(' ', []string{" "})
+	for _, r := range "abc*/-!<>" {
+		b.SetGlyph(r, []string{string(r)})
+	}
+	font, err := b.SetLayout(FitFullWidth).Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+	return font
+}
+
+func TestRenderComment(t *testing.T) {
+	font := commentFont(t)
+
+	tests := []struct {
+		name string
+		text string
+		opts []Option
+		want string
+	}{
+		{name: "line", text: "abc", opts: []Option{WithCommentStyle(CommentSlashes)}, want: "// abc"},
+		{name: "lines", text: "a\nb", opts: []Option{WithCommentStyle(CommentHash)}, want: "# a\n# b"},
+		{name: "trailing spaces trimmed", text: "a  ", opts: []Option{WithCommentStyle(CommentDashes)}, want: "-- a"},
+		{name: "block", text: "ab", opts: []Option{WithCommentStyle(CommentBlock)}, want: "/*\n * ab\n */"},
+		{name: "block closer broken up", text: "a*/b**/", opts: []Option{WithCommentStyle(CommentBlock)}, want: "/*\n * a /b* /\n */"},
+		{name: "html closer broken up", text: "<!-->--->", opts: []Option{WithCommentStyle(CommentHTML)}, want: "<!--\n<! ->- ->\n-->"},
+		{name: "line comments keep closers", text: "*/", opts: []Option{WithCommentStyle(CommentSemi)}, want: ";; */"},
+		{name: "box", text: "a\nabc", opts: []Option{WithCommentStyle(CommentSlashes), WithCommentBox(0)}, want: "// a   //\n// abc //"},
+		{name: "box width", text: "ab", opts: []Option{WithCommentStyle(CommentHash), WithCommentBox(10)}, want: "# ab     #"},
+		{name: "block box", text: "ab", opts: []Option{WithCommentStyle(CommentBlock), WithCommentBox(9)}, want: "/*\n * ab   *\n */"},
+		{name: "html box has no border", text: "a\nab", opts: []Option{WithCommentStyle(CommentHTML), WithCommentBox(0)}, want: "<!--\na\nab\n-->"},
+		{name: "prefix counts against width", text: "abc abc", opts: []Option{WithCommentStyle(CommentREM), WithWidth(10)}, want: "REM abc\nREM abc"},
+		{name: "box wraps", text: "abc abc", opts: []Option{WithCommentStyle(CommentHash), WithCommentBox(8)}, want: "# abc  #\n# abc  #"},
+		{name: "styles ignored", text: "a", opts: []Option{WithCommentStyle(CommentHash), WithRainbow()}, want: "# a"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Render(tt.text, font, tt.opts...)
+			if err != nil {
+				t.Fatalf("Render() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Render() =\n%s\nwant:\n%s", got, tt.want)
+			}
+			var sb strings.Builder
+			if err := RenderTo(&sb, tt.text, font, tt.opts...); err != nil || sb.String() != got {
+				t.Errorf("RenderTo() = %q, %v, want %q", sb.String(), err, got)
+			}
+		})
+	}
+}
+
+func TestRenderCommentBanner(t *testing.T) {
+	font, err := LoadFont("fonts/standard.flf")
+	if err != nil {
+		t.Fatalf("LoadFont() error = %v", err)
+	}
+	plain, err := Render("Parser", font, WithTrimWhitespace(true))
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	got, err := Render("Parser", font, WithCommentStyle(CommentSlashes))
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+
+	plainLines, gotLines := strings.Split(plain, "\n"), strings.Split(got, "\n")
+	if len(gotLines) != len(plainLines) {
+		t.Fatalf("comment has %d lines, want %d", len(gotLines), len(plainLines))
+	}
+	for i, line := range gotLines {
+		if want := strings.TrimRight("// "+plainLines[i], " "); line != want {
+			t.Errorf("line %d = %q, want %q", i, line, want)
+		}
+	}
+}
+
+func TestCommentStyleFor(t *testing.T) {
+	tests := []struct {
+		lang   string
+		want   CommentStyle
+		wantOK bool
+	}{
+		{lang: "go", want: CommentSlashes, wantOK: true},
+		{lang: "Python", want: CommentHash, wantOK: true},
+		{lang: ".sql", want: CommentDashes, wantOK: true},
+		{lang: "c", want: CommentBlock, wantOK: true},
+		{lang: "md", want: CommentHTML, wantOK: true},
+		{lang: "clojure", want: CommentSemi, wantOK: true},
+		{lang: "bat", want: CommentREM, wantOK: true},
+		{lang: "brainfuck"},
+	}
+
+	for _, tt := range tests {
+		got, ok := CommentStyleFor(tt.lang)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("CommentStyleFor(%q) = %+v, %v, want %+v, %v", tt.lang, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
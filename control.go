@@ -0,0 +1,94 @@
+package figgo
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/ryanlewis/figgo/internal/control"
+)
+
+// ControlFile is a parsed FIGlet control file (.flc).
+//
+// Control files map input characters onto font character codes before
+// rendering. They can:
+//   - Translate single characters or ranges ("t" commands and "number number" lines)
+//   - Split translations into stages that run one after another ("f" commands)
+//   - Select how input bytes are decoded: ISO 2022 ("g" commands, the default),
+//     DBCS ("b"), UTF-8 ("u"), Shift-JIS ("j") or HZ ("h")
+//
+// Note that, as in FIGlet, input is decoded byte by byte as ISO 2022 (Latin-1
+// by default) once a control file is in use. Control files meant for UTF-8
+// text should contain a "u" command.
+//
+// A ControlFile is immutable and safe for concurrent use across goroutines.
+type ControlFile struct {
+	// Name is the control file name (e.g., "8859-2")
+	Name string
+
+	file *control.File
+}
+
+// ParseControlFile reads a FIGlet control file from the provided reader.
+//
+// Example:
+//
+//	file, err := os.Open("8859-2.flc")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	defer file.Close()
+//
+//	cf, err := figgo.ParseControlFile(file)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+func ParseControlFile(r io.Reader) (*ControlFile, error) {
+	f, err := control.Parse(r)
+	if err != nil {
+		return nil, err
+	}
+	return &ControlFile{file: f}, nil
+}
+
+// LoadControlFile loads a FIGlet control file from a path on the local filesystem.
+// This is a convenience wrapper around os.Open and ParseControlFile.
+func LoadControlFile(path string) (*ControlFile, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open %q: %w", path, err)
+	}
+	defer file.Close()
+
+	cf, err := ParseControlFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse control file %s: %w", path, err)
+	}
+
+	// Set name based on filename (without extension)
+	cf.Name = deriveNameFromPath(path, true)
+
+	return cf, nil
+}
+
+// WithControlFile applies a FIGlet control file to the input before rendering.
+//
+// The option may be given several times to chain control files, exactly like
+// repeating FIGlet's -C flag:
+//   - Translation stages run in the order the files were given
+//   - The input mode comes from the last file that sets one
+//   - ISO 2022 "g" settings accumulate in order
+//
+// A nil ControlFile is ignored.
+//
+// Example:
+//
+//	cf, _ := figgo.LoadControlFile("upper.flc")
+//	output, err := figgo.Render("hello", font, figgo.WithControlFile(cf))
+func WithControlFile(cf *ControlFile) Option {
+	return func(opts *options) {
+		if cf != nil && cf.file != nil {
+			opts.controlFiles = append(opts.controlFiles, cf.file)
+		}
+	}
+}
@@ -0,0 +1,124 @@
+package figgo
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func mustParseControlFile(t *testing.T, src string) *ControlFile {
+	t.Helper()
+	cf, err := ParseControlFile(strings.NewReader(src))
+	if err != nil {
+		t.Fatalf("ParseControlFile() error = %v", err)
+	}
+	return cf
+}
+
+func TestWithControlFile(t *testing.T) {
+	mockFont := &Font{
+		glyphs: map[rune][]string{
+			'A': {"A", "A"},
+			'B': {"B", "B"},
+			'C': {"C", "C"},
+			'a': {"a", "a"},
+			'?': {"?", "?"},
+			-2:  {"#", "#"},
+		},
+		Height: 2,
+		Layout: FitFullWidth,
+	}
+
+	tests := []struct {
+		name     string
+		controls []string
+		input    string
+		want     string
+	}{
+		{
+			name:  "no control file",
+			input: "a",
+			want:  "a\na",
+		},
+		{
+			name:     "translate range",
+			controls: []string{"t a-c A-C\n"},
+			input:    "abc",
+			want:     "ABC\nABC",
+		},
+		{
+			name:     "translate to negative code",
+			controls: []string{"t x \\-2\n"},
+			input:    "AxB",
+			want:     "A#B\nA#B",
+		},
+		{
+			name:     "chained files apply in order",
+			controls: []string{"t a b\n", "t b C\n"},
+			input:    "a",
+			want:     "C\nC",
+		},
+		{
+			name:     "UTF-8 input mode",
+			controls: []string{"u\nt é A\n"},
+			input:    "é",
+			want:     "A\nA",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var opts []Option
+			for _, src := range tt.controls {
+				opts = append(opts, WithControlFile(mustParseControlFile(t, src)))
+			}
+			got, err := Render(tt.input, mockFont, opts...)
+			if err != nil {
+				t.Fatalf("Render() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Render() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWithControlFileNil(t *testing.T) {
+	opts := defaultOptions()
+	WithControlFile(nil)(opts)
+	if len(opts.controlFiles) != 0 {
+		t.Errorf("WithControlFile(nil) added %d control files, want 0", len(opts.controlFiles))
+	}
+}
+
+func TestParseControlFileError(t *testing.T) {
+	_, err := ParseControlFile(strings.NewReader("t a b\nz\n"))
+	if err == nil {
+		t.Fatal("ParseControlFile() expected error for unknown command")
+	}
+	if !strings.Contains(err.Error(), "line 2") {
+		t.Errorf("ParseControlFile() error = %q, want line number", err)
+	}
+}
+
+func TestLoadControlFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "upper.flc")
+	if err := os.WriteFile(path, []byte("flc2a\nt a-z A-Z\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	cf, err := LoadControlFile(path)
+	if err != nil {
+		t.Fatalf("LoadControlFile() error = %v", err)
+	}
+	if cf.Name != "upper" {
+		t.Errorf("Name = %q, want %q", cf.Name, "upper")
+	}
+
+	_, err = LoadControlFile(filepath.Join(t.TempDir(), "missing.flc"))
+	if !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("LoadControlFile() error = %v, want os.ErrNotExist", err)
+	}
+}
@@ -0,0 +1,235 @@
+package figgo
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+
+	"github.com/ryanlewis/figgo/internal/parser"
+)
+
+// Severity classifies a Diagnostic.
+type Severity int
+
+const (
+	// SeverityWarning marks a problem the parser recovered from
+	SeverityWarning Severity = iota
+	// SeverityError marks a problem that makes the font unusable
+	SeverityError
+)
+
+// String returns "warning" or "error".
+func (s Severity) String() string {
+	if s == SeverityError {
+		return "error"
+	}
+	return "warning"
+}
+
+// DiagnosticKind is a machine-readable diagnostic category. Its values are
+// stable, lower-case and hyphenated, so they can be matched in scripts.
+type DiagnosticKind string
+
+// Diagnostic kinds reported while parsing a font.
+const (
+	// KindInvalidHeader: missing or malformed signature, hardblank or header field
+	KindInvalidHeader = DiagnosticKind(parser.KindInvalidHeader)
+	// KindBaselineOutOfRange: Baseline outside 1..Height (clamped)
+	KindBaselineOutOfRange = DiagnosticKind(parser.KindBaselineOutOfRange)
+	// KindInvalidFullLayout: FullLayout outside 0..32767 (ignored)
+	KindInvalidFullLayout = DiagnosticKind(parser.KindInvalidFullLayout)
+	// KindLayoutMismatch: OldLayout and FullLayout disagree on the horizontal layout
+	KindLayoutMismatch = DiagnosticKind(parser.KindLayoutMismatch)
+	// KindTruncatedComments: the file ends inside the comment block
+	KindTruncatedComments = DiagnosticKind(parser.KindTruncatedComments)
+	// KindTruncatedFont: the file ends before all required ASCII characters
+	KindTruncatedFont = DiagnosticKind(parser.KindTruncatedFont)
+	// KindMissingDeutsch: the file ends before all seven Deutsch characters
+	KindMissingDeutsch = DiagnosticKind(parser.KindMissingDeutsch)
+	// KindTruncatedGlyph: the file ends inside a code-tagged character
+	KindTruncatedGlyph = DiagnosticKind(parser.KindTruncatedGlyph)
+	// KindMaxLengthExceeded: a row is wider than the header's MaxLength
+	KindMaxLengthExceeded = DiagnosticKind(parser.KindMaxLengthExceeded)
+	// KindInconsistentWidth: rows of one character differ in width (padded)
+	KindInconsistentWidth = DiagnosticKind(parser.KindInconsistentWidth)
+	// KindInconsistentEndmark: rows of one character end with different endmarks
+	KindInconsistentEndmark = DiagnosticKind(parser.KindInconsistentEndmark)
+	// KindHardblankEndmark: a character uses the hardblank as its endmark (reported once)
+	KindHardblankEndmark = DiagnosticKind(parser.KindHardblankEndmark)
+	// KindInvalidCodeTag: a line where a code tag was expected is not one
+	KindInvalidCodeTag = DiagnosticKind(parser.KindInvalidCodeTag)
+	// KindIllegalCode: a code-tagged character uses the illegal code -1
+	KindIllegalCode = DiagnosticKind(parser.KindIllegalCode)
+	// KindDuplicateCode: the same code is tagged more than once
+	KindDuplicateCode = DiagnosticKind(parser.KindDuplicateCode)
+	// KindCodetagCountMismatch: fewer code-tagged characters than Codetag_Count
+	KindCodetagCountMismatch = DiagnosticKind(parser.KindCodetagCountMismatch)
+	// KindTrailingData: non-blank lines follow the last declared character
+	KindTrailingData = DiagnosticKind(parser.KindTrailingData)
+	// KindReadError: the underlying reader failed or a line was too long
+	KindReadError = DiagnosticKind(parser.KindReadError)
+)
+
+// Diagnostic describes one problem found in a font file.
+type Diagnostic struct {
+	// Kind is the machine-readable category
+	Kind DiagnosticKind
+	// Severity is SeverityWarning for problems the parser recovered from
+	Severity Severity
+	// Line is the 1-based line in the font file, or 0 if not tied to a line
+	Line int
+	// Code is the character code the problem belongs to, valid when HasCode is true
+	Code    rune
+	HasCode bool
+	// Message is a human-readable description
+	Message string
+}
+
+// String formats the diagnostic as "line N: char C: severity: message (kind)".
+// The line and character parts are omitted when unknown.
+func (d Diagnostic) String() string {
+	s := ""
+	if d.Line > 0 {
+		s += fmt.Sprintf("line %d: ", d.Line)
+	}
+	if d.HasCode {
+		s += fmt.Sprintf("char %d: ", d.Code)
+	}
+	return fmt.Sprintf("%s%s: %s (%s)", s, d.Severity, d.Message, d.Kind)
+}
+
+// FontError reports why a font was rejected. It lists every problem found,
+// each with its position and kind.
+//
+// FontError matches ErrBadFontFormat with errors.Is, as well as any
+// underlying error such as a failing reader.
+//
+// Example:
+//
+//	_, err := figgo.ParseFontWithOptions(r, figgo.ParseOptions{Strict: true})
+//	var fe *figgo.FontError
+//	if errors.As(err, &fe) {
+//	    for _, d := range fe.Diagnostics {
+//	        fmt.Println(d)
+//	    }
+//	}
+type FontError struct {
+	// Diagnostics holds the problems, in file order, all with SeverityError
+	Diagnostics []Diagnostic
+
+	// err is the underlying parser error, if any
+	err error
+}
+
+// Error summarizes the first problem and the number of others.
+func (e *FontError) Error() string {
+	if len(e.Diagnostics) == 0 {
+		return ErrBadFontFormat.Error()
+	}
+	first := e.Diagnostics[0]
+	msg := ErrBadFontFormat.Error() + ": "
+	if first.Line > 0 {
+		msg += fmt.Sprintf("line %d: ", first.Line)
+	}
+	msg += first.Message
+	if n := len(e.Diagnostics) - 1; n > 0 {
+		msg += fmt.Sprintf(" (and %d more)", n)
+	}
+	return msg
+}
+
+// Unwrap returns ErrBadFontFormat and the underlying error, if any.
+func (e *FontError) Unwrap() []error {
+	if e.err != nil {
+		return []error{ErrBadFontFormat, e.err}
+	}
+	return []error{ErrBadFontFormat}
+}
+
+// ParseOptions controls how ParseFontWithOptions treats problems in a font.
+type ParseOptions struct {
+	// Strict rejects fonts with any diagnostic, including those the parser
+	// would otherwise recover from (truncated fonts, ragged rows, rows wider
+	// than MaxLength, missing Deutsch characters, and so on)
+	Strict bool
+}
+
+// ParseFontWithOptions parses a FIGfont like ParseFont, applying opts.
+//
+// In strict mode every diagnostic is treated as an error, and the font is
+// rejected with a *FontError listing all of them. In lenient mode (the
+// default, and what ParseFont does) recoverable problems are available from
+// Font.Diagnostics.
+//
+// Example:
+//
+//	font, err := figgo.ParseFontWithOptions(file, figgo.ParseOptions{Strict: true})
+//	if err != nil {
+//	    log.Fatal(err) // e.g. "bad font format: line 412: rows differ in width, padded to 9"
+//	}
+func ParseFontWithOptions(r io.Reader, opts ParseOptions) (*Font, error) {
+	font, err := ParseFont(r)
+	if err != nil {
+		return nil, err
+	}
+	if opts.Strict && len(font.diagnostics) > 0 {
+		diags := slices.Clone(font.diagnostics)
+		for i := range diags {
+			diags[i].Severity = SeverityError
+		}
+		return nil, &FontError{Diagnostics: diags}
+	}
+	return font, nil
+}
+
+// Diagnostics returns the recoverable problems found while parsing the font,
+// in file order. It returns nil for fonts without problems and for fonts
+// built with FontBuilder. The returned slice is a copy.
+func (f *Font) Diagnostics() []Diagnostic {
+	if f == nil {
+		return nil
+	}
+	return slices.Clone(f.diagnostics)
+}
+
+// convertDiagnostic converts an internal parser.Diagnostic to the public type.
+func convertDiagnostic(d parser.Diagnostic) Diagnostic {
+	severity := SeverityWarning
+	if d.Severity == parser.SeverityError {
+		severity = SeverityError
+	}
+	return Diagnostic{
+		Kind:     DiagnosticKind(d.Kind),
+		Severity: severity,
+		Line:     d.Line,
+		Code:     d.Code,
+		HasCode:  d.HasCode,
+		Message:  d.Message,
+	}
+}
+
+// convertDiagnostics converts the parser's warnings, returning nil if there are none.
+func convertDiagnostics(src []parser.Diagnostic) []Diagnostic {
+	if len(src) == 0 {
+		return nil
+	}
+	dst := make([]Diagnostic, len(src))
+	for i, d := range src {
+		dst[i] = convertDiagnostic(d)
+	}
+	return dst
+}
+
+// wrapParseError turns a fatal parser error into a *FontError, leaving other
+// errors unchanged.
+func wrapParseError(err error) error {
+	var pe *parser.Error
+	if !errors.As(err, &pe) {
+		return err
+	}
+	return &FontError{
+		Diagnostics: []Diagnostic{convertDiagnostic(pe.Diagnostic)},
+		err:         pe.Err,
+	}
+}
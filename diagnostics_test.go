@@ -0,0 +1,154 @@
+package figgo
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// createMinimalFont has no Deutsch characters; the file ends on line 382
+// and character c starts on line 3+4*(c-32).
+const minimalFontEndLine = 382
+
+func TestFontDiagnostics(t *testing.T) {
+	font, err := ParseFont(strings.NewReader(createMinimalFont()))
+	if err != nil {
+		t.Fatalf("ParseFont() error = %v", err)
+	}
+
+	diags := font.Diagnostics()
+	if len(diags) != 1 {
+		t.Fatalf("Diagnostics() = %v, want 1 diagnostic", diags)
+	}
+	want := Diagnostic{
+		Kind:     KindMissingDeutsch,
+		Severity: SeverityWarning,
+		Line:     minimalFontEndLine,
+		Code:     196,
+		HasCode:  true,
+		Message:  "font ends before Deutsch character 196",
+	}
+	if diags[0] != want {
+		t.Errorf("Diagnostics()[0] = %+v, want %+v", diags[0], want)
+	}
+	wantString := "line 382: char 196: warning: font ends before Deutsch character 196 (missing-deutsch)"
+	if got := diags[0].String(); got != wantString {
+		t.Errorf("String() = %q, want %q", got, wantString)
+	}
+
+	// The accessor returns a copy
+	diags[0].Line = 1
+	if font.Diagnostics()[0].Line != minimalFontEndLine {
+		t.Error("modifying Diagnostics() result changed the font")
+	}
+}
+
+func TestParseFontWithOptions(t *testing.T) {
+	ragged := strings.Replace(createMinimalFont(), "A@\nA@\nA@\n", "A@\n@\nA@\n", 1)
+
+	tests := []struct {
+		name      string
+		input     string
+		strict    bool
+		wantErr   bool
+		wantKinds []DiagnosticKind
+		wantLines []int
+		errText   string
+	}{
+		{
+			name:      "lenient accepts recoverable problems",
+			input:     ragged,
+			wantKinds: []DiagnosticKind{KindInconsistentWidth, KindMissingDeutsch},
+			wantLines: []int{135, minimalFontEndLine},
+		},
+		{
+			name:      "strict rejects missing Deutsch characters",
+			input:     createMinimalFont(),
+			strict:    true,
+			wantErr:   true,
+			wantKinds: []DiagnosticKind{KindMissingDeutsch},
+			wantLines: []int{minimalFontEndLine},
+			errText:   "bad font format: line 382: font ends before Deutsch character 196",
+		},
+		{
+			name:      "strict lists every problem",
+			input:     ragged,
+			strict:    true,
+			wantErr:   true,
+			wantKinds: []DiagnosticKind{KindInconsistentWidth, KindMissingDeutsch},
+			wantLines: []int{135, minimalFontEndLine},
+			errText:   "bad font format: line 135: rows differ in width, padded to 1 (and 1 more)",
+		},
+		{
+			name:      "fatal errors are reported in either mode",
+			input:     "flf2a$ 4 3\n",
+			wantErr:   true,
+			wantKinds: []DiagnosticKind{KindInvalidHeader},
+			wantLines: []int{1},
+			errText:   "insufficient header fields",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			font, err := ParseFontWithOptions(strings.NewReader(tt.input), ParseOptions{Strict: tt.strict})
+
+			var diags []Diagnostic
+			wantSeverity := SeverityWarning
+			if tt.wantErr {
+				var fe *FontError
+				if !errors.As(err, &fe) {
+					t.Fatalf("ParseFontWithOptions() error = %v, want *FontError", err)
+				}
+				if !errors.Is(err, ErrBadFontFormat) {
+					t.Error("FontError does not match ErrBadFontFormat")
+				}
+				if !strings.Contains(err.Error(), tt.errText) {
+					t.Errorf("Error() = %q, want it to contain %q", err, tt.errText)
+				}
+				if font != nil {
+					t.Error("ParseFontWithOptions() returned a font with an error")
+				}
+				diags = fe.Diagnostics
+				wantSeverity = SeverityError
+			} else {
+				if err != nil {
+					t.Fatalf("ParseFontWithOptions() error = %v", err)
+				}
+				diags = font.Diagnostics()
+			}
+
+			if len(diags) != len(tt.wantKinds) {
+				t.Fatalf("got %d diagnostics (%v), want %d", len(diags), diags, len(tt.wantKinds))
+			}
+			for i, d := range diags {
+				if d.Kind != tt.wantKinds[i] || d.Line != tt.wantLines[i] || d.Severity != wantSeverity {
+					t.Errorf("diagnostic %d = %v, want %s %s on line %d",
+						i, d, wantSeverity, tt.wantKinds[i], tt.wantLines[i])
+				}
+			}
+		})
+	}
+}
+
+func TestParseFontWithOptionsStrictBundledFonts(t *testing.T) {
+	paths, err := filepath.Glob(filepath.Join("fonts", "*.flf"))
+	if err != nil || len(paths) == 0 {
+		t.Fatalf("no bundled fonts found: %v", err)
+	}
+	for _, path := range paths {
+		t.Run(filepath.Base(path), func(t *testing.T) {
+			file, err := os.Open(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer file.Close()
+
+			if _, err := ParseFontWithOptions(file, ParseOptions{Strict: true}); err != nil {
+				t.Errorf("ParseFontWithOptions(strict) error = %v", err)
+			}
+		})
+	}
+}
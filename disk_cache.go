@@ -13,7 +13,7 @@ import (
 
 var diskCacheMagic = [6]byte{'F', 'I', 'G', 'G', 'O', 0}
 
-const diskCacheVersion uint16 = 1
+const diskCacheVersion uint16 = 6
 const diskCacheHeaderSize = 8 // 6-byte magic + 2-byte version
 
 // DiskCacheConfig configures the on-disk font cache.
@@ -45,46 +45,67 @@ type diskCacheEntry struct {
 }
 
 type fontGobEntry struct {
-	Glyphs         map[rune][]string
-	Name           string
-	Layout         uint32
-	Hardblank      rune
-	Height         int
-	Baseline       int
-	MaxLen         int
-	OldLayout      int
-	PrintDirection int
-	CommentLines   int
+	Glyphs          map[rune][]string
+	Name            string
+	Layout          uint32
+	Hardblank       rune
+	Height          int
+	Baseline        int
+	MaxLen          int
+	OldLayout       int
+	PrintDirection  int
+	CommentLines    int
+	Comments        []string
+	Signature       string
+	FullLayout      int
+	FullLayoutSet   bool
+	CodetagCount    int
+	CodetagCountSet bool
+	Diagnostics     []Diagnostic
 }
 
 func fontToGobEntry(f *Font) fontGobEntry {
 	return fontGobEntry{
-		Glyphs:         f.glyphs,
-		Name:           f.Name,
-		Layout:         uint32(f.Layout),
-		Hardblank:      f.Hardblank,
-		Height:         f.Height,
-		Baseline:       f.Baseline,
-		MaxLen:         f.MaxLen,
-		OldLayout:      f.OldLayout,
-		PrintDirection: f.PrintDirection,
-		CommentLines:   f.CommentLines,
+		Glyphs:          f.glyphs,
+		Name:            f.Name,
+		Layout:          uint32(f.Layout),
+		Hardblank:       f.Hardblank,
+		Height:          f.Height,
+		Baseline:        f.Baseline,
+		MaxLen:          f.MaxLen,
+		OldLayout:       f.OldLayout,
+		PrintDirection:  f.PrintDirection,
+		CommentLines:    f.CommentLines,
+		Comments:        f.comments,
+		Signature:       f.signature,
+		FullLayout:      f.fullLayout,
+		FullLayoutSet:   f.fullLayoutSet,
+		CodetagCount:    f.codetagCount,
+		CodetagCountSet: f.codetagCountSet,
+		Diagnostics:     f.diagnostics,
 	}
 }
 
 func gobEntryToFont(e *fontGobEntry) *Font {
 	// Gob decoder allocates fresh maps/slices, so no deep copy needed.
 	return &Font{
-		glyphs:         e.Glyphs,
-		Name:           e.Name,
-		Layout:         Layout(e.Layout),
-		Hardblank:      e.Hardblank,
-		Height:         e.Height,
-		Baseline:       e.Baseline,
-		MaxLen:         e.MaxLen,
-		OldLayout:      e.OldLayout,
-		PrintDirection: e.PrintDirection,
-		CommentLines:   e.CommentLines,
+		glyphs:          e.Glyphs,
+		Name:            e.Name,
+		Layout:          Layout(e.Layout),
+		Hardblank:       e.Hardblank,
+		Height:          e.Height,
+		Baseline:        e.Baseline,
+		MaxLen:          e.MaxLen,
+		OldLayout:       e.OldLayout,
+		PrintDirection:  e.PrintDirection,
+		CommentLines:    e.CommentLines,
+		comments:        e.Comments,
+		signature:       e.Signature,
+		fullLayout:      e.FullLayout,
+		fullLayoutSet:   e.FullLayoutSet,
+		codetagCount:    e.CodetagCount,
+		codetagCountSet: e.CodetagCountSet,
+		diagnostics:     e.Diagnostics,
 	}
 }
 
@@ -57,6 +57,18 @@ func TestEncodeDecodeRoundTrip(t *testing.T) {
 	if decoded.CommentLines != original.CommentLines {
 		t.Errorf("CommentLines: got %d, want %d", decoded.CommentLines, original.CommentLines)
 	}
+	if decoded.Signature() != original.Signature() {
+		t.Errorf("Signature: got %q, want %q", decoded.Signature(), original.Signature())
+	}
+	if decoded.NormalizedLayout() != original.NormalizedLayout() {
+		t.Errorf("NormalizedLayout: got %v, want %v", decoded.NormalizedLayout(), original.NormalizedLayout())
+	}
+	if got, _ := decoded.CodetagCount(); got != 229 {
+		t.Errorf("CodetagCount: got %d, want 229", got)
+	}
+	if decoded.Info() != original.Info() {
+		t.Errorf("Info: got %+v, want %+v", decoded.Info(), original.Info())
+	}
 
 	// Verify render output matches.
 	origOut, err := Render("Hello", original)
@@ -81,7 +93,7 @@ func TestDecodeInvalidData(t *testing.T) {
 		{"too short", []byte{1, 2, 3}},
 		{"bad magic", []byte("BADMAGXX")},
 		{"bad version", append(diskCacheMagic[:], 0xFF, 0xFF)},
-		{"truncated gob", append(append(diskCacheMagic[:], 1, 0), 0xFF, 0xFF)},
+		{"truncated gob", append(append(diskCacheMagic[:], byte(diskCacheVersion), 0), 0xFF, 0xFF)},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -0,0 +1,82 @@
+package figgo
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/ryanlewis/figgo/internal/parser"
+)
+
+// WriteTo writes the font to w in FIGfont 2.0 (.flf) format and returns the
+// number of bytes written. It implements io.WriterTo.
+//
+// The output is a spec-compliant "flf2a" file:
+//   - A full header (hardblank, height, baseline, max length, old layout,
+//     comment lines, print direction, full layout and codetag count)
+//   - The font's comment lines
+//   - The required ASCII (32-126) and Deutsch characters, each row closed
+//     with an endmark that cannot clash with the glyph
+//   - Every other glyph as a code-tagged character, in ascending code order
+//
+// Round-trip guarantee: parsing the output with ParseFont yields a Font with
+// the same glyphs, Layout, Hardblank, Height, Baseline, MaxLen, OldLayout,
+// PrintDirection, CommentLines and comments. TOIlet fonts are written with
+// the "flf2a" signature; their UTF-8 rows and hardblank are kept as-is.
+//
+// An error wrapping ErrBadFontFormat is returned, before anything is written,
+// if the font cannot be represented: for example a glyph whose row count does
+// not match Height, or a missing required character. The .flf format cannot
+// tell a missing required character from an empty one, so a font may only
+// leave off trailing required characters, and only when it has no glyphs
+// beyond them; the space is always required.
+//
+// Example:
+//
+//	out, err := os.Create("copy.flf")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	defer out.Close()
+//
+//	if _, err := font.WriteTo(out); err != nil {
+//	    log.Fatal(err)
+//	}
+func (f *Font) WriteTo(w io.Writer) (int64, error) {
+	if f == nil {
+		return 0, fmt.Errorf("%w: font cannot be nil", ErrBadFontFormat)
+	}
+
+	fullLayout, fullLayoutSet := f.fullLayout, f.fullLayoutSet
+	if !fullLayoutSet {
+		// Horizontal Layout bits match the FullLayout header bits one for one
+		fullLayout, fullLayoutSet = int(f.Layout&AllKnownMask), true
+	}
+
+	pf := &parser.Font{
+		Comments:       f.comments,
+		Hardblank:      f.Hardblank,
+		Height:         f.Height,
+		Baseline:       f.Baseline,
+		MaxLength:      f.MaxLen,
+		OldLayout:      f.OldLayout,
+		CommentLines:   f.CommentLines,
+		PrintDirection: f.PrintDirection,
+		FullLayout:     fullLayout,
+		FullLayoutSet:  fullLayoutSet,
+		Characters:     f.glyphs,
+	}
+
+	n, err := parser.Encode(w, pf)
+	if errors.Is(err, parser.ErrNotEncodable) {
+		return 0, fmt.Errorf("%w: %w", ErrBadFontFormat, err)
+	}
+	return n, err
+}
+
+// EncodeFont writes font to w in FIGfont 2.0 (.flf) format.
+// It is equivalent to font.WriteTo(w) without the byte count.
+func EncodeFont(w io.Writer, font *Font) error {
+	_, err := font.WriteTo(w)
+	return err
+}
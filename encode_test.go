@@ -0,0 +1,188 @@
+package figgo
+
+import (
+	"bytes"
+	"errors"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestWriteToRoundTrip(t *testing.T) {
+	paths, err := filepath.Glob("fonts/*.flf")
+	if err != nil || len(paths) == 0 {
+		t.Fatalf("no bundled fonts found: %v", err)
+	}
+
+	for _, path := range paths {
+		t.Run(filepath.Base(path), func(t *testing.T) {
+			original, err := LoadFont(path)
+			if err != nil {
+				t.Fatalf("LoadFont() error = %v", err)
+			}
+
+			var buf bytes.Buffer
+			n, err := original.WriteTo(&buf)
+			if err != nil {
+				t.Fatalf("WriteTo() error = %v", err)
+			}
+			if n != int64(buf.Len()) {
+				t.Errorf("WriteTo() returned %d bytes, wrote %d", n, buf.Len())
+			}
+			if !strings.HasPrefix(buf.String(), "flf2a") {
+				t.Errorf("output does not start with the flf2a signature")
+			}
+
+			decoded, err := ParseFont(&buf)
+			if err != nil {
+				t.Fatalf("ParseFont() of encoded font error = %v", err)
+			}
+
+			if !reflect.DeepEqual(decoded.glyphs, original.glyphs) {
+				t.Error("glyphs differ after round trip")
+			}
+			if !reflect.DeepEqual(decoded.comments, original.comments) {
+				t.Error("comments differ after round trip")
+			}
+			if decoded.Layout != original.Layout || decoded.Hardblank != original.Hardblank ||
+				decoded.Height != original.Height || decoded.Baseline != original.Baseline ||
+				decoded.MaxLen != original.MaxLen || decoded.OldLayout != original.OldLayout ||
+				decoded.PrintDirection != original.PrintDirection ||
+				decoded.CommentLines != original.CommentLines {
+				t.Errorf("metadata differs after round trip:\n got %+v\nwant %+v", *decoded, *original)
+			}
+
+			for _, text := range []string{"Hello, World!", "Äöü ß"} {
+				want, _ := Render(text, original)
+				got, _ := Render(text, decoded)
+				if got != want {
+					t.Errorf("Render(%q) differs after round trip:\n got:\n%s\nwant:\n%s", text, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestEncodeFontTOIlet(t *testing.T) {
+	original, err := ParseFont(strings.NewReader(buildTLFFont()))
+	if err != nil {
+		t.Fatalf("ParseFont() error = %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := EncodeFont(&buf, original); err != nil {
+		t.Fatalf("EncodeFont() error = %v", err)
+	}
+	decoded, err := ParseFont(&buf)
+	if err != nil {
+		t.Fatalf("ParseFont() of encoded font error = %v", err)
+	}
+	if !reflect.DeepEqual(decoded.glyphs, original.glyphs) {
+		t.Error("glyphs differ after round trip")
+	}
+	if decoded.Hardblank != '♠' {
+		t.Errorf("Hardblank = %q, want %q", decoded.Hardblank, '♠')
+	}
+}
+
+func TestWriteToDerivesFullLayout(t *testing.T) {
+	font := &Font{
+		glyphs:    map[rune][]string{' ': {" "}, '!': {"!"}},
+		Layout:    FitSmushing | RuleEqualChar | RuleHardblank,
+		Hardblank: '$',
+		Height:    1,
+		Baseline:  1,
+		MaxLen:    3,
+		OldLayout: 33,
+	}
+
+	var buf bytes.Buffer
+	if _, err := font.WriteTo(&buf); err != nil {
+		t.Fatalf("WriteTo() error = %v", err)
+	}
+	if header, _, _ := strings.Cut(buf.String(), "\n"); header != "flf2a$ 1 1 3 33 0 0 161 0" {
+		t.Errorf("header = %q, want %q", header, "flf2a$ 1 1 3 33 0 0 161 0")
+	}
+
+	decoded, err := ParseFont(&buf)
+	if err != nil {
+		t.Fatalf("ParseFont() error = %v", err)
+	}
+	if decoded.Layout != font.Layout {
+		t.Errorf("Layout = %v, want %v", decoded.Layout, font.Layout)
+	}
+}
+
+func TestWriteToErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		font *Font
+	}{
+		{"nil font", nil},
+		{"missing hardblank", &Font{glyphs: map[rune][]string{' ': {" "}}, Height: 1, Baseline: 1, MaxLen: 1}},
+		{"wrong row count", &Font{glyphs: map[rune][]string{' ': {" ", " "}}, Hardblank: '$', Height: 1, Baseline: 1, MaxLen: 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			err := EncodeFont(&buf, tt.font)
+			if !errors.Is(err, ErrBadFontFormat) {
+				t.Errorf("EncodeFont() error = %v, want ErrBadFontFormat", err)
+			}
+			if buf.Len() != 0 {
+				t.Errorf("EncodeFont() wrote %d bytes before failing", buf.Len())
+			}
+		})
+	}
+}
+
+func TestWriteToMissingRequiredCharacters(t *testing.T) {
+	glyph := []string{"#", "#"}
+	tests := []struct {
+		name    string
+		runes   []rune
+		wantErr bool
+	}{
+		{"trailing characters left off", []rune{' ', '!', '"'}, false},
+		{"gap in required characters", []rune{' ', 'A', 'C'}, true},
+		{"missing required before code tags", []rune{' ', '!', 0x263A}, true},
+		{"missing space", []rune{'!'}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			font := &Font{glyphs: map[rune][]string{}, Hardblank: '$', Height: 2, Baseline: 2, MaxLen: 3}
+			for _, r := range tt.runes {
+				font.glyphs[r] = glyph
+			}
+
+			var buf bytes.Buffer
+			err := EncodeFont(&buf, font)
+			if tt.wantErr {
+				if !errors.Is(err, ErrBadFontFormat) {
+					t.Errorf("EncodeFont() error = %v, want ErrBadFontFormat", err)
+				}
+				if buf.Len() != 0 {
+					t.Errorf("EncodeFont() wrote %d bytes before failing", buf.Len())
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("EncodeFont() error = %v", err)
+			}
+
+			decoded, err := ParseFont(&buf)
+			if err != nil {
+				t.Fatalf("ParseFont() of encoded font error = %v", err)
+			}
+			if !reflect.DeepEqual(decoded.glyphs, font.glyphs) {
+				t.Errorf("glyphs = %q, want %q", decoded.glyphs, font.glyphs)
+			}
+			if _, ok := decoded.Glyph('A'); ok {
+				t.Error("Glyph('A') defined after round trip, want missing")
+			}
+		})
+	}
+}
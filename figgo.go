@@ -35,8 +35,10 @@ import (
 	"os"
 	"path"
 	"path/filepath"
+	"slices"
 	"strings"
 
+	"github.com/ryanlewis/figgo/internal/control"
 	"github.com/ryanlewis/figgo/internal/debug"
 	"github.com/ryanlewis/figgo/internal/parser"
 	"github.com/ryanlewis/figgo/internal/renderer"
@@ -47,6 +49,8 @@ const (
 	maxZipSize = 5 << 20 // 5 MiB
 	// maxEntrySize is the maximum size of a single font file in a ZIP (5 MiB)
 	maxEntrySize = 5 << 20 // 5 MiB
+	// maxPackSize is the maximum total uncompressed size of a font pack's entries (64 MiB)
+	maxPackSize = 64 << 20 // 64 MiB
 )
 
 // ParseFont reads a FIGfont from the provided reader and returns a Font instance.
@@ -55,12 +59,22 @@ const (
 // ParseFont supports both plain text and ZIP-compressed FIGfont files.
 // ZIP-compressed fonts are automatically detected by checking for ZIP magic bytes.
 // When a ZIP file is detected, the first file (skipping directory entries) in
-// the archive is extracted and parsed.
+// the archive is extracted and parsed. Use LoadFontPack or OpenFontArchive for
+// archives holding a collection of fonts.
 //
 // ParseFont expects a valid FIGfont v2 format file with at least the required
 // ASCII characters (32-126). The font's layout settings are normalized according
 // to the FIGfont specification.
 //
+// Malformed fonts are rejected with a *FontError giving the line and kind of
+// the problem. Problems the parser can recover from, such as a truncated font
+// or rows of uneven width, are available from Font.Diagnostics; use
+// ParseFontWithOptions with Strict set to reject such fonts instead.
+//
+// TOIlet fonts (.tlf, signature "tlf2a") are detected from their header and
+// loaded the same way, including their UTF-8 glyph rows, multi-byte
+// hardblanks and endmarks, and code-tagged Unicode characters.
+//
 // Example:
 //
 //	file, err := os.Open("standard.flf")
@@ -110,7 +124,7 @@ func ParseFont(r io.Reader) (*Font, error) {
 	// Handle as regular FLF file - can stream directly
 	pf, err := parser.Parse(combined)
 	if err != nil {
-		return nil, err
+		return nil, wrapParseError(err)
 	}
 	// Convert internal parser.Font to public Font type
 	return convertParserFont(pf)
@@ -172,7 +186,7 @@ func parseCompressedFont(data []byte) (*Font, error) {
 	// Parse the extracted font data
 	pf, err := parser.Parse(bytes.NewReader(fontData))
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse font from ZIP: %w", err)
+		return nil, fmt.Errorf("failed to parse font from ZIP: %w", wrapParseError(err))
 	}
 
 	// Convert internal parser.Font to public Font type
@@ -270,8 +284,8 @@ func deriveNameFromPath(filePath string, useOSPath bool) string {
 		return base
 	}
 
-	// Handle both .flf and .flc extensions (for potential future control file support)
-	if ext == ".flf" || ext == ".flc" {
+	// Handle .flf (font), .tlf (TOIlet font) and .flc (control file) extensions
+	if ext == ".flf" || ext == ".tlf" || ext == ".flc" {
 		return strings.TrimSuffix(base, ext)
 	}
 
@@ -307,7 +321,7 @@ func LoadFontDir(dir, fontName string) (*Font, error) {
 // - embed.FS (embedded fonts at compile time)
 // - os.DirFS (local filesystem directories)
 // - Any custom fs.FS implementation
-// - Supports both plain .flf and ZIP-compressed fonts
+// - Supports plain .flf, TOIlet .tlf and ZIP-compressed fonts
 //
 // Path Requirements:
 // - Must be a valid fs.ValidPath (no leading slash, no backslashes)
@@ -405,16 +419,23 @@ func convertParserFont(pf *parser.Font) (*Font, error) {
 	layout := normalized.ToLayout()
 
 	return &Font{
-		glyphs:         cloneGlyphs(pf.Characters),
-		Name:           "", // Will be set based on filename or metadata
-		Layout:         layout,
-		Hardblank:      pf.Hardblank,
-		Height:         pf.Height,
-		Baseline:       pf.Baseline,
-		MaxLen:         pf.MaxLength,
-		OldLayout:      pf.OldLayout,
-		PrintDirection: pf.PrintDirection,
-		CommentLines:   pf.CommentLines,
+		glyphs:          cloneGlyphs(pf.Characters),
+		Name:            "", // Will be set based on filename or metadata
+		Layout:          layout,
+		Hardblank:       pf.Hardblank,
+		Height:          pf.Height,
+		Baseline:        pf.Baseline,
+		MaxLen:          pf.MaxLength,
+		OldLayout:       pf.OldLayout,
+		PrintDirection:  pf.PrintDirection,
+		CommentLines:    pf.CommentLines,
+		comments:        slices.Clone(pf.Comments),
+		signature:       pf.Signature,
+		fullLayout:      pf.FullLayout,
+		fullLayoutSet:   pf.FullLayoutSet,
+		codetagCount:    pf.CodetagCount,
+		codetagCountSet: pf.CodetagCountSet,
+		diagnostics:     convertDiagnostics(pf.Diagnostics),
 	}, nil
 }
 
@@ -429,6 +450,7 @@ func convertParserFont(pf *parser.Font) (*Font, error) {
 //
 // Default Behavior:
 // - Uses font's built-in layout and print direction if not overridden
+// - Renders the font's "missing character" (code 0) for unknown runes when defined
 // - Replaces unknown runes with '?' (unless WithUnknownRune is used)
 // - Preserves trailing whitespace (unless WithTrimWhitespace is used)
 //
@@ -449,23 +471,8 @@ func RenderTo(w io.Writer, text string, f *Font, opts ...Option) error {
 	if f == nil {
 		return ErrUnknownFont
 	}
-	options := defaultOptions()
-	for _, opt := range opts {
-		opt(options)
-	}
-
-	// Default to font's layout and direction if not specified
-	if options.layout == nil {
-		l := f.Layout
-		options.layout = &l
-	}
-	if options.printDirection == nil {
-		d := f.PrintDirection
-		options.printDirection = &d
-	}
-
-	// Validate layout options
-	if err := validateLayout(options); err != nil {
+	options, err := resolveOptions(f, opts)
+	if err != nil {
 		return err
 	}
 
@@ -526,11 +533,30 @@ func RenderTo(w io.Writer, text string, f *Font, opts ...Option) error {
 		})
 	}
 
+	return renderTo(w, text, f, options)
+}
+
+// renderTo writes text rendered with resolved options in the format they
+// ask for: a comment, cells packed or styled, or plain text.
+func renderTo(w io.Writer, text string, f *Font, options *options) error {
+	switch {
+	case options.comment != nil:
+		return writeComment(w, text, f, options)
+	case options.styled() || options.pixelMode != PixelOff:
+		return writeCells(w, text, f, options)
+	}
+
 	// Convert public Font back to internal parser.Font for renderer
 	pf := convertToParserFont(f)
 	return renderer.RenderTo(w, text, pf, options.toInternal())
 }
 
+// plain reports whether the options ask for plain text output, which the
+// renderer writes directly.
+func (o *options) plain() bool {
+	return o.comment == nil && !o.styled() && o.pixelMode == PixelOff
+}
+
 // Render converts text to ASCII art using the specified font and options.
 // It returns the rendered text as a string.
 //
@@ -554,14 +580,33 @@ func Render(text string, f *Font, opts ...Option) (string, error) {
 	if f == nil {
 		return "", ErrUnknownFont
 	}
+	options, err := resolveOptions(f, opts)
+	if err != nil {
+		return "", err
+	}
+	if !options.plain() {
+		var sb strings.Builder
+		if err := renderTo(&sb, text, f, options); err != nil {
+			return "", err
+		}
+		return sb.String(), nil
+	}
+	// Convert public Font back to internal parser.Font for renderer
+	pf := convertToParserFont(f)
+	return renderer.Render(text, pf, options.toInternal())
+}
+
+// resolveOptions applies opts over the font's defaults and validates them.
+func resolveOptions(f *Font, opts []Option) (*options, error) {
 	options := defaultOptions()
 	for _, opt := range opts {
 		opt(options)
 	}
 
-	// Default to font's layout and direction if not specified
+	// Default to font's layout and direction if not specified. The font's
+	// vertical layout only applies when requested, as in FIGlet 2.2
 	if options.layout == nil {
-		l := f.Layout
+		l := f.Layout &^ verticalLayoutMask
 		options.layout = &l
 	}
 	if options.printDirection == nil {
@@ -571,11 +616,9 @@ func Render(text string, f *Font, opts ...Option) (string, error) {
 
 	// Validate layout options
 	if err := validateLayout(options); err != nil {
-		return "", err
+		return nil, err
 	}
-	// Convert public Font back to internal parser.Font for renderer
-	pf := convertToParserFont(f)
-	return renderer.Render(text, pf, options.toInternal())
+	return options, nil
 }
 
 // convertToParserFont converts public Font to internal parser.Font
@@ -591,8 +634,7 @@ func convertToParserFont(f *Font) *parser.Font {
 		OldLayout: f.OldLayout,
 		// Note: We don't set FullLayout here as f.Layout is the normalized
 		// horizontal layout bitmask, not the original FIGfont header value.
-		// The renderer currently only consumes horizontal layout settings.
-		// TODO: Thread vertical bits into renderer API when vertical rendering is implemented.
+		// Vertical layout reaches the renderer through WithVerticalLayout.
 		PrintDirection: f.PrintDirection,
 		CommentLines:   f.CommentLines,
 		// IMPORTANT: We pass glyphs directly without cloning. The renderer MUST NOT
@@ -613,6 +655,21 @@ func validateLayout(opts *options) error {
 		// Update with normalized layout
 		*opts.layout = normalized
 	}
+	if opts.verticalMode != nil && (*opts.verticalMode < ModeFull || *opts.verticalMode > ModeSmushingUniversal) {
+		return fmt.Errorf("%w: %d", ErrInvalidAxisMode, *opts.verticalMode)
+	}
+	if opts.justify < JustifyLeft || opts.justify > JustifyAuto {
+		return fmt.Errorf("%w: %d", ErrInvalidJustify, opts.justify)
+	}
+	if opts.wrapMode < WrapWordThenChar || opts.wrapMode > WrapChar {
+		return fmt.Errorf("%w: %d", ErrInvalidWrapMode, opts.wrapMode)
+	}
+	if opts.colorMode != nil && (*opts.colorMode < ColorNone || *opts.colorMode > ColorTrue) {
+		return fmt.Errorf("%w: %d", ErrInvalidColorMode, *opts.colorMode)
+	}
+	if opts.pixelMode < PixelOff || opts.pixelMode > PixelBraille {
+		return fmt.Errorf("%w: %d", ErrInvalidPixelMode, opts.pixelMode)
+	}
 	return nil
 }
 
@@ -620,12 +677,29 @@ func validateLayout(opts *options) error {
 type Option func(*options)
 
 type options struct {
-	layout         *Layout
-	printDirection *int
-	unknownRune    *rune
-	trimWhitespace bool
-	width          *int
-	debug          *debug.Session // Debug session for tracing
+	layout          *Layout
+	printDirection  *int
+	unknownRune     *rune
+	trimWhitespace  bool
+	width           *int
+	debug           *debug.Session         // Debug session for tracing
+	controlFiles    []*control.File        // Control files applied to the input, in order
+	verticalMode    *AxisMode              // Vertical layout mode (nil = full height)
+	verticalRules   uint8                  // Vertical smushing rules (bits 0-4)
+	justify         Justify                // Alignment of each output line
+	paragraphMode   bool                   // Reflow single newlines as spaces
+	wrapMode        WrapMode               // How lines are broken at the width
+	hyphenate       bool                   // Hyphenate words broken across lines
+	balancedWrap    bool                   // Balance line lengths instead of filling greedily
+	stylers         []func(CellInfo) Style // Style functions, layered in order
+	colorMode       *ColorMode             // Escape sequences for styled output (nil = 256 colours)
+	htmlClassPrefix string                 // Class name prefix for RenderHTML ("" = no classes)
+	htmlWrapper     *string                // RenderHTML wrapper element (nil = div)
+	svg             svgOptions             // RenderSVG layout and colours
+	image           imageOptions           // RenderImage layout, colours and shading
+	pixelMode       PixelMode              // Packing of output into pixel characters
+	comment         *CommentStyle          // Comment syntax to wrap output in (nil = none)
+	commentBox      *int                   // Comment box width (nil = no box, 0 = widest line)
 }
 
 func defaultOptions() *options {
@@ -635,7 +709,11 @@ func defaultOptions() *options {
 func (o *options) toInternal() *renderer.Options {
 	rendererOpts := &renderer.Options{}
 	if o.layout != nil {
-		rendererOpts.Layout = int(*o.layout)
+		rendererOpts.Layout = int(*o.layout &^ verticalLayoutMask)
+		// Vertical bits match the FullLayout header bits 8-14
+		vertical := parseFullLayout(int(*o.layout & verticalLayoutMask))
+		rendererOpts.VerticalMode = int(vertical.VertMode)
+		rendererOpts.VerticalRules = int(vertical.VertRules)
 	}
 	if o.printDirection != nil {
 		rendererOpts.PrintDirection = o.printDirection
@@ -646,7 +724,27 @@ func (o *options) toInternal() *renderer.Options {
 	rendererOpts.TrimWhitespace = o.trimWhitespace
 	if o.width != nil {
 		rendererOpts.Width = o.width
+		// Lines wrap at the packed width; each packed column covers scaleX
+		if scaleX, _ := o.pixelMode.pixelScale(); scaleX > 1 {
+			width := *o.width*scaleX - (scaleX - 1)
+			rendererOpts.Width = &width
+		}
 	}
 	rendererOpts.Debug = o.debug
+	if len(o.controlFiles) > 0 {
+		rendererOpts.InputFilter = control.NewDecoder(o.controlFiles).Apply
+	}
+	rendererOpts.Justify = int(o.justify)
+	rendererOpts.ParagraphMode = o.paragraphMode
+	rendererOpts.WrapMode = int(o.wrapMode)
+	rendererOpts.Hyphenate = o.hyphenate
+	rendererOpts.BalancedWrap = o.balancedWrap
+	if o.verticalMode != nil {
+		rendererOpts.VerticalMode = int(*o.verticalMode)
+		rendererOpts.VerticalRules = int(o.verticalRules)
+		if *o.verticalMode == ModeSmushingControlled && o.verticalRules == 0 {
+			rendererOpts.VerticalMode = renderer.VertSmushUniversal
+		}
+	}
 	return rendererOpts
 }
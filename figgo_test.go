@@ -512,3 +512,74 @@ func TestStandardFontRendering(t *testing.T) {
 		})
 	}
 }
+
+func TestStandardFontCodeTaggedRendering(t *testing.T) {
+	font, err := LoadFont("fonts/standard.flf")
+	if err != nil {
+		t.Fatalf("Failed to load font: %v", err)
+	}
+
+	// Latin-1 and Latin Extended-A characters are code-tagged in standard.flf
+	// and must render from the font rather than failing as unsupported.
+	for _, input := range []string{"©", "café", "Ā"} {
+		t.Run(input, func(t *testing.T) {
+			got, err := Render(input, font)
+			if err != nil {
+				t.Fatalf("Render(%q) failed: %v", input, err)
+			}
+			if strings.TrimSpace(got) == "" {
+				t.Errorf("Render(%q) returned blank output", input)
+			}
+		})
+	}
+}
+
+// buildTLFFont returns a single-row TOIlet font with a multi-byte hardblank
+// ('♠') and endmark ('│'), UTF-8 glyph rows and one code-tagged character.
+func buildTLFFont() string {
+	var sb strings.Builder
+	sb.WriteString("tlf2a♠ 1 1 4 -1 1 0 0 0\n")
+	sb.WriteString("TOIlet test font\n")
+	for i := 32; i <= 126; i++ {
+		switch i {
+		case ' ':
+			sb.WriteString("♠││\n")
+		case 'A':
+			sb.WriteString("▄▀▄││\n")
+		default:
+			sb.WriteString("▀││\n")
+		}
+	}
+	sb.WriteString(strings.Repeat("ü││\n", 7))
+	sb.WriteString("0x2603 SNOWMAN\n☃││\n")
+	return sb.String()
+}
+
+func TestTOIletFontRendering(t *testing.T) {
+	font, err := ParseFont(strings.NewReader(buildTLFFont()))
+	if err != nil {
+		t.Fatalf("ParseFont() error = %v", err)
+	}
+	if font.Hardblank != '♠' {
+		t.Errorf("Hardblank = %q, want %q", font.Hardblank, '♠')
+	}
+
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"A A", "▄▀▄ ▄▀▄"},
+		{"A☃", "▄▀▄☃"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := Render(tt.input, font, WithLayout(FitFullWidth))
+			if err != nil {
+				t.Fatalf("Render(%q) error = %v", tt.input, err)
+			}
+			if got != tt.want {
+				t.Errorf("Render(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
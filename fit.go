@@ -0,0 +1,94 @@
+package figgo
+
+import (
+	"slices"
+)
+
+// FitResult is the output of RenderFit and the font and layout chosen for it.
+type FitResult struct {
+	// Output is the rendered text
+	Output string
+	// Font is the candidate font the text was rendered in
+	Font *Font
+	// Layout is the layout the text was rendered with
+	Layout Layout
+}
+
+// RenderFit renders text in the first font and layout that fits within width
+// columns without wrapping, for output that has to follow a resizing
+// terminal.
+//
+// Candidates are tried in order of preference, usually largest first. Each
+// one is tried at full width, then kerned, then smushed with its own rules
+// (universal smushing if it has none), and the first that needs no line
+// breaks other than the text's own newlines wins. Trying is done with
+// Measure, so only the chosen output is rendered.
+//
+// opts apply as for Render, except that width and the horizontal layout are
+// set by RenderFit; vertical layout options are kept. Width is clamped like
+// WithWidth.
+//
+// If nothing fits, RenderFit returns the text rendered in the last candidate,
+// smushed and wrapped at width, together with ErrNoFit.
+//
+// Example:
+//
+//	res, err := figgo.RenderFit(title, termWidth, []*figgo.Font{big, standard, small})
+//	if err != nil && !errors.Is(err, figgo.ErrNoFit) {
+//	    return err
+//	}
+//	fmt.Println(res.Output)
+func RenderFit(text string, width int, candidates []*Font, opts ...Option) (FitResult, error) {
+	if len(candidates) == 0 {
+		return FitResult{}, ErrUnknownFont
+	}
+	fitOpts := append(slices.Clip(opts), WithWidth(width))
+
+	var result FitResult
+	for _, f := range candidates {
+		if f == nil {
+			return FitResult{}, ErrUnknownFont
+		}
+		options, err := resolveOptions(f, fitOpts)
+		if err != nil {
+			return FitResult{}, err
+		}
+		vertical := *options.layout & verticalLayoutMask
+		options.debug = nil // only the chosen output is traced
+
+		for _, layout := range fitLayouts(f) {
+			layout |= vertical
+			options.layout = &layout
+			m, err := measure(text, f, options)
+			if err != nil {
+				return FitResult{}, err
+			}
+			result = FitResult{Font: f, Layout: layout}
+			if m.WrappedLines == 0 && m.Width <= *options.width {
+				return renderFit(text, result, fitOpts)
+			}
+		}
+	}
+
+	result, err := renderFit(text, result, fitOpts)
+	if err != nil {
+		return FitResult{}, err
+	}
+	return result, ErrNoFit
+}
+
+// fitLayouts returns the horizontal layouts RenderFit tries for f, loosest
+// first.
+func fitLayouts(f *Font) [3]Layout {
+	return [3]Layout{FitFullWidth, FitKerning, FitSmushing | f.Layout.Rules()}
+}
+
+// renderFit renders text with the font and layout chosen in res.
+func renderFit(text string, res FitResult, opts []Option) (FitResult, error) {
+	output, err := Render(text, res.Font, append(opts, WithLayout(res.Layout))...)
+	if err != nil {
+		return FitResult{}, err
+	}
+	res.Output = output
+	return res, nil
+}
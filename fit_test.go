@@ -0,0 +1,111 @@
+package figgo
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestRenderFit(t *testing.T) {
+	big, err := LoadFont("fonts/big.flf")
+	if err != nil {
+		t.Fatalf("LoadFont() error = %v", err)
+	}
+	standard, err := LoadFont("fonts/standard.flf")
+	if err != nil {
+		t.Fatalf("LoadFont() error = %v", err)
+	}
+	candidates := []*Font{big, standard}
+
+	// "Hello World" is 76, 62 and 54 columns wide in big at full width,
+	// kerned and smushed, and 73, 60 and 52 in standard
+	tests := []struct {
+		name       string
+		text       string
+		width      int
+		opts       []Option
+		wantFont   *Font
+		wantLayout Layout
+		wantErr    error
+	}{
+		{name: "full width fits", text: "Hello World", width: 80, wantFont: big, wantLayout: FitFullWidth},
+		{name: "kerning fits", text: "Hello World", width: 70, wantFont: big, wantLayout: FitKerning},
+		{name: "smushing fits", text: "Hello World", width: 58, wantFont: big, wantLayout: FitSmushing | big.Layout.Rules()},
+		{name: "next font", text: "Hello World", width: 53, wantFont: standard, wantLayout: FitSmushing | standard.Layout.Rules()},
+		{name: "own newlines are not wrapping", text: "Hello\nWorld", width: 50, wantFont: big, wantLayout: FitFullWidth},
+		{name: "layout option replaced", text: "Hello World", width: 80, opts: []Option{WithLayout(FitSmushing)}, wantFont: big, wantLayout: FitFullWidth},
+		{
+			name:       "vertical layout kept",
+			text:       "Hello\nWorld",
+			width:      50,
+			opts:       []Option{WithLayout(FitKerning | FitVerticalFitting)},
+			wantFont:   big,
+			wantLayout: FitFullWidth | FitVerticalFitting,
+		},
+		{
+			name:       "nothing fits",
+			text:       "Hello World",
+			width:      40,
+			wantFont:   standard,
+			wantLayout: FitSmushing | standard.Layout.Rules(),
+			wantErr:    ErrNoFit,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := RenderFit(tt.text, tt.width, candidates, tt.opts...)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("RenderFit() error = %v, want %v", err, tt.wantErr)
+			}
+			if res.Font != tt.wantFont {
+				t.Errorf("RenderFit() font = %s, want %s", res.Font.Name, tt.wantFont.Name)
+			}
+			if res.Layout != tt.wantLayout {
+				t.Errorf("RenderFit() layout = %v, want %v", res.Layout, tt.wantLayout)
+			}
+
+			want, err := Render(tt.text, res.Font, append(tt.opts, WithWidth(tt.width), WithLayout(res.Layout))...)
+			if err != nil {
+				t.Fatalf("Render() error = %v", err)
+			}
+			if res.Output != want {
+				t.Errorf("RenderFit() output:\n%s\nwant:\n%s", res.Output, want)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			for _, row := range strings.Split(res.Output, "\n") {
+				if n := len([]rune(row)); n > tt.width {
+					t.Errorf("row is %d columns wide, want at most %d: %q", n, tt.width, row)
+				}
+			}
+		})
+	}
+}
+
+func TestRenderFitErrors(t *testing.T) {
+	standard, err := LoadFont("fonts/standard.flf")
+	if err != nil {
+		t.Fatalf("LoadFont() error = %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		candidates []*Font
+		opts       []Option
+		wantErr    error
+	}{
+		{name: "no candidates", wantErr: ErrUnknownFont},
+		{name: "nil candidate", candidates: []*Font{standard, nil}, opts: []Option{WithWidth(10)}, wantErr: ErrUnknownFont},
+		{name: "invalid option", candidates: []*Font{standard}, opts: []Option{WithJustify(Justify(9))}, wantErr: ErrInvalidJustify},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := RenderFit("Hello World", 20, tt.candidates, tt.opts...); !errors.Is(err, tt.wantErr) {
+				t.Errorf("RenderFit() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -429,6 +429,19 @@ Test font
 $@
 $@
 $@
+$@@
+ @
+|@
+ @
+ @@
+`),
+		},
+		"fonts/future.tlf": &fstest.MapFile{
+			Data: []byte(`tlf2a$ 4 3 10 -1 5
+Test font
+$@
+$@
+$@
 $@@
  @
 |@
@@ -446,6 +459,10 @@ $@@
 			path:     "fonts/my-custom-font.flf",
 			wantName: "my-custom-font",
 		},
+		{
+			path:     "fonts/future.tlf",
+			wantName: "future",
+		},
 		{
 			path:     "deep/nested/path/special.flf",
 			wantName: "special",
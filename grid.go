@@ -0,0 +1,99 @@
+package figgo
+
+import (
+	"strings"
+)
+
+// Cell is one character of rendered output and where it came from.
+type Cell struct {
+	// Rune is the character as Render writes it; hardblanks are spaces
+	Rune rune
+	// Source is the index of the input rune that produced the cell, counting
+	// runes of the text (or characters after control files are applied), or
+	// -1 when no input rune did: justification padding and inserted hyphens
+	Source int
+	// Smushed is true when two visible characters were merged into the cell
+	Smushed bool
+	// SmushRule names the rule that merged them, as debug traces do
+	// ("equal", "underscore", "hierarchy", "pair", "bigx", "hardblank" or
+	// "universal"), or "vertical" when output lines were smushed together
+	SmushRule string
+	// Hardblank is true when the cell holds one of the font's hardblanks
+	Hardblank bool
+}
+
+// Grid is rendered output as rows of cells. Joining the runes of each row
+// with newlines gives exactly what Render returns.
+type Grid struct {
+	// Rows holds the output rows; rows are only as long as their content,
+	// so their lengths vary
+	Rows [][]Cell
+}
+
+// At returns the cell at the given row and column, and false when the
+// position is outside the output. Use it to hit-test a rendered banner.
+func (g *Grid) At(row, col int) (Cell, bool) {
+	if g == nil || row < 0 || row >= len(g.Rows) || col < 0 || col >= len(g.Rows[row]) {
+		return Cell{}, false
+	}
+	return g.Rows[row][col], true
+}
+
+// String returns the grid as text, as Render would.
+func (g *Grid) String() string {
+	if g == nil {
+		return ""
+	}
+	var sb strings.Builder
+	for i, row := range g.Rows {
+		if i > 0 {
+			sb.WriteByte('\n')
+		}
+		for _, c := range row {
+			sb.WriteRune(c.Rune)
+		}
+	}
+	return sb.String()
+}
+
+// RenderGrid renders text like Render, but returns a Grid recording for each
+// output character the input rune it came from, whether it was smushed and
+// by which rule, and whether it is a hardblank. Use it to colour output by
+// input character, hit-test it in a TUI, or diff it cell by cell.
+//
+// Where two characters smush, the cell belongs to the later one in the
+// input. RenderGrid takes the same options and returns the same errors as
+// Render.
+//
+// Example:
+//
+//	grid, err := figgo.RenderGrid("Hi", font)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	if c, ok := grid.At(2, 4); ok && c.Source >= 0 {
+//	    fmt.Printf("clicked on input rune %d\n", c.Source)
+//	}
+func RenderGrid(text string, f *Font, opts ...Option) (*Grid, error) {
+	if f == nil {
+		return nil, ErrUnknownFont
+	}
+	options, err := resolveOptions(f, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := renderCells(text, f, options)
+	if err != nil {
+		return nil, err
+	}
+
+	grid := &Grid{Rows: make([][]Cell, len(rows))}
+	for i, row := range rows {
+		grid.Rows[i] = make([]Cell, len(row))
+		for j, c := range row {
+			grid.Rows[i][j] = Cell(c)
+		}
+	}
+	return grid, nil
+}
@@ -0,0 +1,238 @@
+package figgo
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestRenderGridMatchesRender(t *testing.T) {
+	optionSets := map[string][]Option{
+		"default":         nil,
+		"full width":      {WithLayout(FitFullWidth)},
+		"kerning":         {WithLayout(FitKerning)},
+		"universal":       {WithLayout(FitSmushing)},
+		"right to left":   {WithPrintDirection(1)},
+		"wrapped":         {WithWidth(30)},
+		"trimmed":         {WithWidth(30), WithTrimWhitespace(true)},
+		"centred":         {WithWidth(70), WithJustify(JustifyCenter)},
+		"right justified": {WithWidth(30), WithJustify(JustifyRight), WithPrintDirection(1)},
+		"balanced":        {WithWidth(60), WithBalancedWrap(true)},
+		"paragraph":       {WithWidth(60), WithParagraphMode(true)},
+		"hyphenated":      {WithWidth(25), WithWrapMode(WrapChar), WithHyphenation(true)},
+		"word wrap":       {WithWidth(25), WithWrapMode(WrapWord)},
+		"vertical fit":    {WithVerticalLayout(ModeFitting, 0)},
+		"vertical smush":  {WithVerticalLayout(ModeSmushingUniversal, 0), WithTrimWhitespace(true)},
+		"control file":    {WithControlFile(mustParseControlFile(t, "flc2a\nt a-z A-Z\n"))},
+	}
+	texts := []string{"", "Hello World", "Supercalifragilistic expialidocious", "one\ntwo  three\n\nfour", "é ∑ ok"}
+
+	for _, name := range []string{"standard", "big", "slant", "small"} {
+		font, err := LoadFont("fonts/" + name + ".flf")
+		if err != nil {
+			t.Fatalf("LoadFont() error = %v", err)
+		}
+		for setName, opts := range optionSets {
+			opts := append(opts, WithUnknownRune('?'))
+			for _, text := range texts {
+				want, err := Render(text, font, opts...)
+				if err != nil {
+					t.Fatalf("Render() error = %v", err)
+				}
+				grid, err := RenderGrid(text, font, opts...)
+				if err != nil {
+					t.Fatalf("RenderGrid() error = %v", err)
+				}
+				if got := grid.String(); got != want {
+					t.Errorf("%s, %s, %q: RenderGrid() =\n%s\nwant:\n%s", name, setName, text, got, want)
+					continue
+				}
+
+				// Control files decode the input, so sources count its codes
+				if setName == "control file" {
+					continue
+				}
+				n := len([]rune(text))
+				for _, row := range grid.Rows {
+					for _, c := range row {
+						if c.Source < -1 || c.Source >= n {
+							t.Errorf("%s, %s, %q: cell %+v has source out of range", name, setName, text, c)
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestRenderGridSources(t *testing.T) {
+	font, err := LoadFont("fonts/standard.flf")
+	if err != nil {
+		t.Fatalf("LoadFont() error = %v", err)
+	}
+
+	tests := []struct {
+		name string
+		text string
+		opts []Option
+		// want is the sources of the first row of each output line, with
+		// runs of the same source collapsed
+		want [][]int
+	}{
+		{name: "left to right", text: "Hi!", opts: []Option{WithLayout(FitFullWidth)}, want: [][]int{{0, 1, 2}}},
+		{name: "right to left", text: "Hi!", opts: []Option{WithLayout(FitFullWidth), WithPrintDirection(1)}, want: [][]int{{2, 1, 0}}},
+		{name: "newline skipped", text: "a\nb", opts: []Option{WithLayout(FitFullWidth)}, want: [][]int{{0}, {2}}},
+		{name: "multibyte runes count once", text: "é!", opts: []Option{WithLayout(FitFullWidth), WithUnknownRune('?')}, want: [][]int{{0, 1}}},
+		{name: "wrapped at a space", text: "ab cd", opts: []Option{WithLayout(FitFullWidth), WithWidth(20)}, want: [][]int{{0, 1}, {3, 4}}},
+		{name: "balanced", text: "aa bb cc dd ee", opts: []Option{WithWidth(40), WithBalancedWrap(true)}, want: [][]int{{0, 1, 2, 3, 4}, {6, 7, 8, 9, 10, 11, 12, 13}}},
+		{name: "centred", text: "Hi", opts: []Option{WithLayout(FitFullWidth), WithWidth(40), WithJustify(JustifyCenter)}, want: [][]int{{-1, 0, 1}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			grid, err := RenderGrid(tt.text, font, tt.opts...)
+			if err != nil {
+				t.Fatalf("RenderGrid() error = %v", err)
+			}
+			var got [][]int
+			for i := 0; i < len(grid.Rows); i += font.Height {
+				var sources []int
+				for _, c := range grid.Rows[i] {
+					sources = append(sources, c.Source)
+				}
+				got = append(got, slices.Compact(sources))
+			}
+			if !slices.EqualFunc(got, tt.want, slices.Equal) {
+				t.Errorf("sources = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderGridSmushing(t *testing.T) {
+	font, err := LoadFont("fonts/standard.flf")
+	if err != nil {
+		t.Fatalf("LoadFont() error = %v", err)
+	}
+	rules := []string{"equal", "underscore", "hierarchy", "pair", "bigx", "hardblank", "universal"}
+
+	tests := []struct {
+		name        string
+		layout      Layout
+		wantSmushed bool
+	}{
+		{name: "full width", layout: FitFullWidth},
+		{name: "kerning", layout: FitKerning},
+		{name: "controlled", layout: font.Layout, wantSmushed: true},
+		{name: "universal", layout: FitSmushing, wantSmushed: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			grid, err := RenderGrid("Hello World", font, WithLayout(tt.layout))
+			if err != nil {
+				t.Fatalf("RenderGrid() error = %v", err)
+			}
+			smushed := false
+			for _, row := range grid.Rows {
+				for _, c := range row {
+					if !c.Smushed {
+						continue
+					}
+					smushed = true
+					if !slices.Contains(rules, c.SmushRule) {
+						t.Errorf("cell %+v has an unexpected rule", c)
+					}
+					if c.Source < 1 {
+						t.Errorf("cell %+v should belong to the later character", c)
+					}
+				}
+			}
+			if smushed != tt.wantSmushed {
+				t.Errorf("smushed cells = %v, want %v", smushed, tt.wantSmushed)
+			}
+		})
+	}
+}
+
+func TestRenderGridHardblanksAndHyphens(t *testing.T) {
+	font, err := LoadFont("fonts/standard.flf")
+	if err != nil {
+		t.Fatalf("LoadFont() error = %v", err)
+	}
+
+	// The standard font's space is a hardblank
+	grid, err := RenderGrid("a b", font, WithLayout(FitFullWidth))
+	if err != nil {
+		t.Fatalf("RenderGrid() error = %v", err)
+	}
+	hardblank := false
+	for _, c := range grid.Rows[0] {
+		if c.Hardblank {
+			hardblank = true
+			if c.Rune != ' ' || c.Source != 1 {
+				t.Errorf("hardblank cell = %+v, want a space from input rune 1", c)
+			}
+		}
+	}
+	if !hardblank {
+		t.Error("no hardblank cells in the space glyph")
+	}
+
+	// Hyphens inserted at breaks are not from the input
+	grid, err = RenderGrid("Supercalifragilistic", font, WithWidth(30), WithWrapMode(WrapChar), WithHyphenation(true))
+	if err != nil {
+		t.Fatalf("RenderGrid() error = %v", err)
+	}
+	hyphen := false
+	for _, row := range grid.Rows {
+		for _, c := range row {
+			if c.Source == -1 && c.Rune != ' ' {
+				hyphen = true
+			}
+		}
+	}
+	if !hyphen {
+		t.Error("no hyphen cells without a source")
+	}
+}
+
+func TestGridAt(t *testing.T) {
+	grid := &Grid{Rows: [][]Cell{{{Rune: 'a'}, {Rune: 'b', Source: 1}}, nil}}
+
+	tests := []struct {
+		row, col int
+		want     Cell
+		wantOK   bool
+	}{
+		{row: 0, col: 1, want: Cell{Rune: 'b', Source: 1}, wantOK: true},
+		{row: 0, col: 2},
+		{row: 1, col: 0},
+		{row: -1, col: 0},
+		{row: 2, col: 0},
+	}
+
+	for _, tt := range tests {
+		got, ok := grid.At(tt.row, tt.col)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("At(%d, %d) = %+v, %v, want %+v, %v", tt.row, tt.col, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+func TestRenderGridErrors(t *testing.T) {
+	font, err := LoadFont("fonts/standard.flf")
+	if err != nil {
+		t.Fatalf("LoadFont() error = %v", err)
+	}
+
+	if _, err := RenderGrid("Hi", nil); err != ErrUnknownFont {
+		t.Errorf("RenderGrid(nil font) error = %v, want %v", err, ErrUnknownFont)
+	}
+	if _, err := RenderGrid("Hi", font, WithWrapMode(WrapMode(9))); !errors.Is(err, ErrInvalidWrapMode) {
+		t.Errorf("RenderGrid() error = %v, want %v", err, ErrInvalidWrapMode)
+	}
+	if _, err := RenderGrid("∑", font); err == nil {
+		t.Error("RenderGrid() expected error for an unsupported rune")
+	}
+}
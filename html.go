@@ -0,0 +1,184 @@
+package figgo
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/ryanlewis/figgo/internal/renderer"
+)
+
+// htmlElementPattern matches the element names WithHTMLWrapper accepts.
+var htmlElementPattern = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9-]*$`)
+
+// WithHTMLClasses makes RenderHTML tag each run of output with class names
+// built from prefix: "<prefix>-g<n>" for cells drawn by input rune n and
+// "<prefix>-smushed" for cells where two characters merged. The wrapper
+// element gets the class prefix itself. Style rules can then colour glyphs
+// without inline CSS.
+func WithHTMLClasses(prefix string) Option {
+	return func(opts *options) {
+		opts.htmlClassPrefix = prefix
+	}
+}
+
+// WithHTMLWrapper sets the element RenderHTML wraps the <pre> block in; the
+// default is "div". An empty tag writes the <pre> block alone.
+func WithHTMLWrapper(tag string) Option {
+	return func(opts *options) {
+		opts.htmlWrapper = &tag
+	}
+}
+
+// RenderHTML writes text rendered as Render would as an HTML <pre> block,
+// with the characters escaped. The block is marked up for screen readers
+// to read the original text rather than the art: the wrapper element has
+// role="img" and an aria-label of the text, and the <pre> is aria-hidden.
+//
+// Style options (WithStyle, WithGradient, ...) become inline CSS on <span>
+// elements, and WithHTMLClasses adds class names for each input character.
+// Layout, wrapping and every other option behave exactly as for Render.
+//
+// Example:
+//
+//	err := figgo.RenderHTML(w, "Hello", font,
+//	    figgo.WithHTMLClasses("banner"), figgo.WithRainbow())
+//	// <div class="banner" role="img" aria-label="Hello"><pre aria-hidden="true">...
+func RenderHTML(w io.Writer, text string, f *Font, opts ...Option) error {
+	if f == nil {
+		return ErrUnknownFont
+	}
+	options, err := resolveOptions(f, opts)
+	if err != nil {
+		return err
+	}
+	wrapper := "div"
+	if options.htmlWrapper != nil {
+		wrapper = *options.htmlWrapper
+	}
+	if wrapper != "" && !htmlElementPattern.MatchString(wrapper) {
+		return fmt.Errorf("%w: %q", ErrInvalidHTMLElement, wrapper)
+	}
+
+	rows, err := renderCells(text, f, options)
+	if err != nil {
+		return err
+	}
+
+	// The label reads the text as one line
+	label := strings.Join(strings.Fields(text), " ")
+	var buf []byte
+	openAttrs := fmt.Sprintf(" role=\"img\" aria-label=\"%s\"", escapeHTML(label))
+	if options.htmlClassPrefix != "" {
+		openAttrs = fmt.Sprintf(" class=\"%s\"", escapeHTML(options.htmlClassPrefix)) + openAttrs
+	}
+	if wrapper != "" {
+		buf = fmt.Appendf(buf, "<%s%s><pre aria-hidden=\"true\">", wrapper, openAttrs)
+	} else {
+		buf = fmt.Appendf(buf, "<pre%s>", openAttrs)
+	}
+
+	styled := options.styled()
+	width := gridWidth(rows)
+	for i, row := range rows {
+		if i > 0 {
+			buf = append(buf, '\n')
+		}
+		var cur Style
+		curAttrs := ""
+		for j, c := range row {
+			var attrs string
+			if styled {
+				cur = options.cellStyle(CellInfo{Cell: Cell(c), Row: i, Col: j, Width: width, Height: len(rows)}, cur)
+				attrs = styleCSS(cur)
+			}
+			attrs = cellClasses(c, options.htmlClassPrefix) + attrs
+			if attrs != curAttrs {
+				if curAttrs != "" {
+					buf = append(buf, "</span>"...)
+				}
+				if attrs != "" {
+					buf = fmt.Appendf(buf, "<span%s>", attrs)
+				}
+				curAttrs = attrs
+			}
+			buf = appendEscapedRune(buf, c.Rune)
+		}
+		if curAttrs != "" {
+			buf = append(buf, "</span>"...)
+		}
+	}
+
+	buf = append(buf, "</pre>"...)
+	if wrapper != "" {
+		buf = fmt.Appendf(buf, "</%s>", wrapper)
+	}
+	_, err = w.Write(buf)
+	return err
+}
+
+// cellClasses returns the class attribute for a cell, or "" without a
+// class prefix or when no class applies.
+func cellClasses(c renderer.Cell, prefix string) string {
+	if prefix == "" || c.Source < 0 {
+		return ""
+	}
+	classes := prefix + "-g" + strconv.Itoa(c.Source)
+	if c.Smushed {
+		classes += " " + prefix + "-smushed"
+	}
+	return " class=\"" + escapeHTML(classes) + "\""
+}
+
+// styleCSS returns the style attribute for a style, or "" for plain text.
+func styleCSS(s Style) string {
+	var css []string
+	if s.Fg != 0 {
+		css = append(css, "color:"+s.Fg.String())
+	}
+	if s.Bg != 0 {
+		css = append(css, "background-color:"+s.Bg.String())
+	}
+	if s.Bold {
+		css = append(css, "font-weight:bold")
+	}
+	if s.Italic {
+		css = append(css, "font-style:italic")
+	}
+	if s.Underline {
+		css = append(css, "text-decoration:underline")
+	}
+	if len(css) == 0 {
+		return ""
+	}
+	return " style=\"" + strings.Join(css, ";") + "\""
+}
+
+// escapeHTML escapes text for use in HTML content and attribute values.
+func escapeHTML(s string) string {
+	var buf []byte
+	for _, r := range s {
+		buf = appendEscapedRune(buf, r)
+	}
+	return string(buf)
+}
+
+// appendEscapedRune appends r, escaped for HTML.
+func appendEscapedRune(buf []byte, r rune) []byte {
+	switch r {
+	case '&':
+		return append(buf, "&amp;"...)
+	case '<':
+		return append(buf, "&lt;"...)
+	case '>':
+		return append(buf, "&gt;"...)
+	case '"':
+		return append(buf, "&quot;"...)
+	case '\'':
+		return append(buf, "&#39;"...)
+	}
+	return utf8.AppendRune(buf, r)
+}
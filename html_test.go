@@ -0,0 +1,174 @@
+package figgo
+
+import (
+	"errors"
+	"html"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+// preContent matches the <pre> block of RenderHTML output.
+var preContent = regexp.MustCompile(`(?s)<pre[^>]*>(.*)</pre>`)
+
+// tagPattern matches HTML tags.
+var tagPattern = regexp.MustCompile(`<[^>]*>`)
+
+// htmlText returns the text of the <pre> block of RenderHTML output.
+func htmlText(t *testing.T, out string) string {
+	t.Helper()
+	m := preContent.FindStringSubmatch(out)
+	if m == nil {
+		t.Fatalf("no <pre> block in %q", out)
+	}
+	return html.UnescapeString(tagPattern.ReplaceAllString(m[1], ""))
+}
+
+func TestRenderHTMLMatchesRender(t *testing.T) {
+	optionSets := map[string][]Option{
+		"default":        nil,
+		"kerning":        {WithLayout(FitKerning)},
+		"right to left":  {WithPrintDirection(1)},
+		"wrapped":        {WithWidth(30), WithTrimWhitespace(true)},
+		"centred":        {WithWidth(70), WithJustify(JustifyCenter)},
+		"vertical smush": {WithVerticalLayout(ModeSmushingUniversal, 0)},
+		"classes":        {WithHTMLClasses("fig")},
+		"styled":         {WithHTMLClasses("fig"), WithRainbow(), WithGlyphStyles(Style{Bg: RGB(0, 0, 0)}, Style{})},
+	}
+	texts := []string{"", "Hello World", "<a href='x'> & \"y\"", "one\ntwo"}
+
+	for _, name := range []string{"standard", "big", "slant", "small"} {
+		font, err := LoadFont("fonts/" + name + ".flf")
+		if err != nil {
+			t.Fatalf("LoadFont() error = %v", err)
+		}
+		for setName, opts := range optionSets {
+			for _, text := range texts {
+				want, err := Render(text, font, append(opts, WithColorMode(ColorNone))...)
+				if err != nil {
+					t.Fatalf("Render() error = %v", err)
+				}
+				var sb strings.Builder
+				if err := RenderHTML(&sb, text, font, opts...); err != nil {
+					t.Fatalf("RenderHTML() error = %v", err)
+				}
+				if got := htmlText(t, sb.String()); got != want {
+					t.Errorf("%s, %s, %q: RenderHTML() text =\n%s\nwant:\n%s", name, setName, text, got, want)
+				}
+			}
+		}
+	}
+}
+
+func TestRenderHTML(t *testing.T) {
+	font, err := LoadFont("fonts/standard.flf")
+	if err != nil {
+		t.Fatalf("LoadFont() error = %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		text     string
+		opts     []Option
+		contains []string
+		excludes []string
+	}{
+		{
+			name:     "default wrapper",
+			text:     "Hi",
+			contains: []string{`<div role="img" aria-label="Hi"><pre aria-hidden="true">`, `</pre></div>`},
+			excludes: []string{"<span"},
+		},
+		{
+			name:     "custom wrapper",
+			text:     "Hi",
+			opts:     []Option{WithHTMLWrapper("figure")},
+			contains: []string{`<figure role="img" aria-label="Hi"><pre aria-hidden="true">`, `</pre></figure>`},
+		},
+		{
+			name:     "no wrapper",
+			text:     "Hi",
+			opts:     []Option{WithHTMLWrapper("")},
+			contains: []string{`<pre role="img" aria-label="Hi">`},
+			excludes: []string{"<div", "aria-hidden"},
+		},
+		{
+			name:     "label escaped and on one line",
+			text:     "a<b\n\"c\"",
+			contains: []string{`aria-label="a&lt;b &quot;c&quot;"`},
+		},
+		{
+			name:     "art escaped",
+			text:     "n&",
+			opts:     []Option{WithLayout(FitFullWidth)},
+			contains: []string{"| &#39;_", "(_&gt;  &lt;"},
+		},
+		{
+			name:     "classes",
+			text:     "Hi",
+			opts:     []Option{WithHTMLClasses("fig")},
+			contains: []string{`<div class="fig" role="img"`, `<span class="fig-g0">`, `<span class="fig-g1">`},
+		},
+		{
+			name:     "smushed class",
+			text:     "Hello",
+			opts:     []Option{WithHTMLClasses("fig"), WithLayout(FitSmushing)},
+			contains: []string{"fig-smushed"},
+		},
+		{
+			name:     "inline styles",
+			text:     "Hi",
+			opts:     []Option{WithGlyphStyles(Style{Fg: RGB(255, 0, 0), Bold: true}, Style{Bg: RGB(0, 0, 255), Underline: true})},
+			contains: []string{`<span style="color:#ff0000;font-weight:bold">`, `<span style="background-color:#0000ff;text-decoration:underline">`},
+		},
+		{
+			name:     "styles off",
+			text:     "Hi",
+			opts:     []Option{WithRainbow(), WithColorMode(ColorNone)},
+			excludes: []string{"style="},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var sb strings.Builder
+			if err := RenderHTML(&sb, tt.text, font, tt.opts...); err != nil {
+				t.Fatalf("RenderHTML() error = %v", err)
+			}
+			got := sb.String()
+			for _, s := range tt.contains {
+				if !strings.Contains(got, s) {
+					t.Errorf("RenderHTML() = %q, want it to contain %q", got, s)
+				}
+			}
+			for _, s := range tt.excludes {
+				if strings.Contains(got, s) {
+					t.Errorf("RenderHTML() = %q, want it not to contain %q", got, s)
+				}
+			}
+		})
+	}
+}
+
+func TestRenderHTMLErrors(t *testing.T) {
+	font, err := LoadFont("fonts/standard.flf")
+	if err != nil {
+		t.Fatalf("LoadFont() error = %v", err)
+	}
+	var sb strings.Builder
+
+	if err := RenderHTML(&sb, "Hi", nil); err != ErrUnknownFont {
+		t.Errorf("RenderHTML(nil font) error = %v, want %v", err, ErrUnknownFont)
+	}
+	for _, tag := range []string{"div class", "<div>", "1a"} {
+		if err := RenderHTML(&sb, "Hi", font, WithHTMLWrapper(tag)); !errors.Is(err, ErrInvalidHTMLElement) {
+			t.Errorf("RenderHTML(wrapper %q) error = %v, want %v", tag, err, ErrInvalidHTMLElement)
+		}
+	}
+	if err := RenderHTML(&sb, "∑", font); err == nil {
+		t.Error("RenderHTML() expected error for an unsupported rune")
+	}
+	if sb.Len() != 0 {
+		t.Errorf("RenderHTML() wrote %q on error", sb.String())
+	}
+}
@@ -0,0 +1,167 @@
+package figgo
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"strings"
+)
+
+// Image layout defaults
+const (
+	defaultImageCellWidth  = 8
+	defaultImageCellHeight = 16
+)
+
+// imageOptions holds the settings of RenderImage.
+type imageOptions struct {
+	cellWidth, cellHeight int
+	fg, bg                Color
+	shade                 func(r rune) float64
+}
+
+// WithImageCellSize sets the size in pixels of the block RenderImage draws
+// for each character; the default is 8 by 16. Sizes that are not positive
+// are ignored.
+func WithImageCellSize(width, height int) Option {
+	return func(opts *options) {
+		if width > 0 && height > 0 {
+			opts.image.cellWidth, opts.image.cellHeight = width, height
+		}
+	}
+}
+
+// WithImageColors sets the block and background colours of RenderImage
+// output. The default Color gives black blocks on a transparent background.
+// Style options (WithStyle, WithRainbow, ...) colour cells over these.
+func WithImageColors(fg, bg Color) Option {
+	return func(opts *options) {
+		opts.image.fg, opts.image.bg = fg, bg
+	}
+}
+
+// WithImageShading shades each block of RenderImage output by its
+// character: shade returns how much of the block colour to use, from 0 (the
+// background) to 1 (solid). ShadeByDensity shades by how much ink the
+// character has. Without shading every block is solid.
+func WithImageShading(shade func(r rune) float64) Option {
+	return func(opts *options) {
+		opts.image.shade = shade
+	}
+}
+
+// ShadeByDensity shades a character by how dense it looks in text: "#" and
+// "@" are solid, letters and digits nearly so, line-drawing characters such
+// as "|" and "/" lighter, and "." and "," lightest.
+func ShadeByDensity(r rune) float64 {
+	switch {
+	case strings.ContainsRune("#@%&$█", r):
+		return 1
+	case strings.ContainsRune(".,'`", r):
+		return 0.25
+	case strings.ContainsRune(":;-~^\"", r):
+		return 0.4
+	case strings.ContainsRune("_|/\\()<>[]{}!=+*", r):
+		return 0.6
+	default:
+		return 0.8
+	}
+}
+
+// RenderImage renders text as Render would and draws the output as an
+// image, filling a block of pixels for each character that is not a space.
+// It needs no font rasteriser, so the image looks the same everywhere; use
+// image/png to encode it for chat clients that mangle monospace text.
+//
+// Style options give per-cell colours: foreground colours colour the block
+// and background colours fill the cell behind it. Layout, wrapping and
+// every other option behave exactly as for Render, except WithPixelMode,
+// which RenderImage ignores.
+//
+// Example:
+//
+//	img, err := figgo.RenderImage("Hello", font,
+//	    figgo.WithImageCellSize(4, 8), figgo.WithImageShading(figgo.ShadeByDensity))
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	err = png.Encode(w, img)
+func RenderImage(text string, f *Font, opts ...Option) (image.Image, error) {
+	if f == nil {
+		return nil, ErrUnknownFont
+	}
+	options, err := resolveOptions(f, opts)
+	if err != nil {
+		return nil, err
+	}
+	options.pixelMode = PixelOff // the image is drawn in pixels already
+	rows, err := renderCells(text, f, options)
+	if err != nil {
+		return nil, err
+	}
+
+	settings := options.image
+	if settings.cellWidth == 0 {
+		settings.cellWidth, settings.cellHeight = defaultImageCellWidth, defaultImageCellHeight
+	}
+	fg := settings.fg
+	if fg == 0 {
+		fg = RGB(0, 0, 0)
+	}
+
+	width := gridWidth(rows)
+	img := image.NewNRGBA(image.Rect(0, 0, width*settings.cellWidth, len(rows)*settings.cellHeight))
+	draw.Draw(img, img.Bounds(), image.NewUniform(nrgba(settings.bg)), image.Point{}, draw.Src)
+
+	styled := options.styled()
+	for i, row := range rows {
+		var cur Style
+		for j, c := range row {
+			if styled {
+				cur = options.cellStyle(CellInfo{Cell: Cell(c), Row: i, Col: j, Width: width, Height: len(rows)}, cur)
+			}
+			cellFg, cellBg := fg, settings.bg
+			if cur.Fg != 0 {
+				cellFg = cur.Fg
+			}
+			if cur.Bg != 0 {
+				cellBg = cur.Bg
+			}
+
+			coverage := 0.0
+			if c.Rune != ' ' {
+				coverage = 1
+				if settings.shade != nil {
+					coverage = min(max(settings.shade(c.Rune), 0), 1)
+				}
+			}
+			if coverage == 0 && cellBg == settings.bg {
+				continue // the background is already drawn
+			}
+
+			cell := image.Rect(j*settings.cellWidth, i*settings.cellHeight, (j+1)*settings.cellWidth, (i+1)*settings.cellHeight)
+			draw.Draw(img, cell, image.NewUniform(blendNRGBA(cellBg, cellFg, coverage)), image.Point{}, draw.Src)
+		}
+	}
+	return img, nil
+}
+
+// nrgba returns c as an image colour; the default Color is transparent.
+func nrgba(c Color) color.NRGBA {
+	r, g, b, ok := c.RGB()
+	if !ok {
+		return color.NRGBA{}
+	}
+	return color.NRGBA{R: r, G: g, B: b, A: 0xff}
+}
+
+// blendNRGBA returns the colour of fg drawn with the given coverage over bg.
+// Over a transparent background, coverage becomes fg's opacity.
+func blendNRGBA(bg, fg Color, coverage float64) color.NRGBA {
+	if bg == 0 {
+		c := nrgba(fg)
+		c.A = uint8(coverage*0xff + 0.5)
+		return c
+	}
+	return nrgba(blendColors(bg, fg, coverage))
+}
@@ -0,0 +1,179 @@
+package figgo
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	"image/png"
+	"testing"
+)
+
+func TestRenderImage(t *testing.T) {
+	font, err := LoadFont("fonts/standard.flf")
+	if err != nil {
+		t.Fatalf("LoadFont() error = %v", err)
+	}
+	white, red := RGB(255, 255, 255), RGB(255, 0, 0)
+
+	tests := []struct {
+		name      string
+		opts      []Option
+		cellW     int
+		cellH     int
+		wantInk   func(c Cell) color.NRGBA
+		wantBlank color.NRGBA
+	}{
+		{
+			name:      "defaults",
+			cellW:     8,
+			cellH:     16,
+			wantInk:   func(Cell) color.NRGBA { return color.NRGBA{A: 0xff} },
+			wantBlank: color.NRGBA{},
+		},
+		{
+			name:      "sized and coloured",
+			opts:      []Option{WithImageCellSize(3, 5), WithImageColors(red, white)},
+			cellW:     3,
+			cellH:     5,
+			wantInk:   func(Cell) color.NRGBA { return color.NRGBA{R: 0xff, A: 0xff} },
+			wantBlank: color.NRGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff},
+		},
+		{
+			name:  "shaded",
+			opts:  []Option{WithImageCellSize(2, 2), WithImageColors(0, white), WithImageShading(ShadeByDensity)},
+			cellW: 2,
+			cellH: 2,
+			wantInk: func(c Cell) color.NRGBA {
+				v := uint8(255 - 255*ShadeByDensity(c.Rune) + 0.5)
+				return color.NRGBA{R: v, G: v, B: v, A: 0xff}
+			},
+			wantBlank: color.NRGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff},
+		},
+		{
+			name:  "shaded over transparent",
+			opts:  []Option{WithImageCellSize(1, 1), WithImageShading(func(r rune) float64 { return 0.5 })},
+			cellW: 1,
+			cellH: 1,
+			wantInk: func(Cell) color.NRGBA {
+				return color.NRGBA{A: 0x80}
+			},
+			wantBlank: color.NRGBA{},
+		},
+		{
+			name:  "styled",
+			opts:  []Option{WithImageCellSize(1, 1), WithGlyphStyles(Style{Fg: red}, Style{})},
+			cellW: 1,
+			cellH: 1,
+			wantInk: func(c Cell) color.NRGBA {
+				if c.Source%2 == 0 { // styles cycle
+					return color.NRGBA{R: 0xff, A: 0xff}
+				}
+				return color.NRGBA{A: 0xff}
+			},
+			wantBlank: color.NRGBA{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			grid, err := RenderGrid("Hi #.", font, tt.opts...)
+			if err != nil {
+				t.Fatalf("RenderGrid() error = %v", err)
+			}
+			img, err := RenderImage("Hi #.", font, tt.opts...)
+			if err != nil {
+				t.Fatalf("RenderImage() error = %v", err)
+			}
+
+			width := 0
+			for _, row := range grid.Rows {
+				width = max(width, len(row))
+			}
+			if want := image.Rect(0, 0, width*tt.cellW, len(grid.Rows)*tt.cellH); img.Bounds() != want {
+				t.Fatalf("Bounds() = %v, want %v", img.Bounds(), want)
+			}
+
+			for y := 0; y < img.Bounds().Dy(); y++ {
+				for x := 0; x < img.Bounds().Dx(); x++ {
+					want := tt.wantBlank
+					if c, ok := grid.At(y/tt.cellH, x/tt.cellW); ok && c.Rune != ' ' {
+						want = tt.wantInk(c)
+					}
+					if got := color.NRGBAModel.Convert(img.At(x, y)).(color.NRGBA); got != want {
+						t.Fatalf("pixel (%d, %d) = %v, want %v", x, y, got, want)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestRenderImageBackgroundStyle(t *testing.T) {
+	font, err := LoadFont("fonts/standard.flf")
+	if err != nil {
+		t.Fatalf("LoadFont() error = %v", err)
+	}
+	blue := RGB(0, 0, 255)
+	img, err := RenderImage("Hi", font, WithImageCellSize(1, 1), WithGlyphStyles(Style{Bg: blue}))
+	if err != nil {
+		t.Fatalf("RenderImage() error = %v", err)
+	}
+	// The glyph's spaces get the background; the blocks stay black
+	if got := color.NRGBAModel.Convert(img.At(0, 0)); got != (color.NRGBA{B: 0xff, A: 0xff}) {
+		t.Errorf("space pixel = %v, want blue", got)
+	}
+	if got := color.NRGBAModel.Convert(img.At(0, 1)); got != (color.NRGBA{A: 0xff}) {
+		t.Errorf("block pixel = %v, want black", got)
+	}
+}
+
+func TestRenderImagePNG(t *testing.T) {
+	font, err := LoadFont("fonts/small.flf")
+	if err != nil {
+		t.Fatalf("LoadFont() error = %v", err)
+	}
+	img, err := RenderImage("figgo", font, WithRainbow())
+	if err != nil {
+		t.Fatalf("RenderImage() error = %v", err)
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatalf("png.Encode() error = %v", err)
+	}
+	decoded, err := png.Decode(&buf)
+	if err != nil {
+		t.Fatalf("png.Decode() error = %v", err)
+	}
+	if decoded.Bounds() != img.Bounds() {
+		t.Errorf("decoded bounds = %v, want %v", decoded.Bounds(), img.Bounds())
+	}
+}
+
+func TestShadeByDensity(t *testing.T) {
+	order := []rune{'.', ':', '|', 'a', '#'}
+	for i := 1; i < len(order); i++ {
+		if ShadeByDensity(order[i-1]) >= ShadeByDensity(order[i]) {
+			t.Errorf("ShadeByDensity(%q) should be lighter than ShadeByDensity(%q)", order[i-1], order[i])
+		}
+	}
+	if ShadeByDensity('#') != 1 {
+		t.Errorf("ShadeByDensity('#') = %v, want 1", ShadeByDensity('#'))
+	}
+}
+
+func TestRenderImageErrors(t *testing.T) {
+	font, err := LoadFont("fonts/standard.flf")
+	if err != nil {
+		t.Fatalf("LoadFont() error = %v", err)
+	}
+	if _, err := RenderImage("Hi", nil); err != ErrUnknownFont {
+		t.Errorf("RenderImage(nil font) error = %v, want %v", err, ErrUnknownFont)
+	}
+	if _, err := RenderImage("Hi", font, WithWrapMode(WrapMode(9))); !errors.Is(err, ErrInvalidWrapMode) {
+		t.Errorf("RenderImage() error = %v, want %v", err, ErrInvalidWrapMode)
+	}
+	if _, err := RenderImage("∑", font); err == nil {
+		t.Error("RenderImage() expected error for an unsupported rune")
+	}
+}
@@ -0,0 +1,391 @@
+// Package control implements FIGlet control file (.flc) parsing and input decoding.
+//
+// A control file describes how input is turned into FIGfont character codes:
+// an input mode decides how bytes are decoded (ISO 2022, DBCS, UTF-8, Shift-JIS
+// or HZ), and one or more stages of "t" translations then remap the decoded
+// codes. Several control files can be chained; their stages run in order.
+package control
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// InputMode selects how input bytes are decoded into character codes.
+type InputMode int
+
+const (
+	// ModeISO2022 decodes bytes as ISO 2022 (the FIGlet default). With the
+	// initial G0=ASCII and G1=Latin-1 settings this is plain Latin-1.
+	ModeISO2022 InputMode = iota
+	// ModeDBCS reads bytes 128-255 as the high byte of a two-byte character ("b").
+	ModeDBCS
+	// ModeUTF8 decodes UTF-8; malformed sequences become code 128 ("u").
+	ModeUTF8
+	// ModeShiftJIS reads bytes 128-159 and 224-239 as the high byte of a
+	// two-byte character ("j").
+	ModeShiftJIS
+	// ModeHZ decodes HZ-encoded Chinese text ("h").
+	ModeHZ
+)
+
+const (
+	// invalidUTF8Code is the code produced for malformed UTF-8 input (per spec)
+	invalidUTF8Code = 128
+	// maxLineLength bounds a single control file line
+	maxLineLength = 64 * 1024
+)
+
+// ErrInvalidControlFile is returned when a control file cannot be parsed.
+var ErrInvalidControlFile = errors.New("invalid control file")
+
+// translation maps the inclusive range lo..hi onto lo+offset..hi+offset.
+type translation struct {
+	lo, hi, offset rune
+}
+
+// charsetCmd records a "g" command so chained files apply them in order.
+type charsetCmd struct {
+	invoke byte // 0 to designate slot, 'L' or 'R' to invoke slot into a half
+	slot   int  // G0-G3
+	code   rune // Designator shifted into bits 16+, plus 0x80 for 96-character sets
+	double bool // 94x94 character set
+}
+
+// File is a parsed control file. It is immutable after Parse returns.
+type File struct {
+	stages   [][]translation
+	charsets []charsetCmd
+	mode     InputMode
+	modeSet  bool
+}
+
+// Parse reads a control file.
+//
+// Blank lines and lines starting with '#' are ignored. The optional "flc2a"
+// signature is an "f" command by another name and is harmless. Unknown
+// commands and malformed translations are reported with their line number.
+func Parse(r io.Reader) (*File, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 4096), maxLineLength)
+
+	f := &File{}
+	var stage []translation
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		line := strings.TrimRight(scanner.Text(), "\r")
+		trimmed := strings.TrimLeft(line, " \t")
+		if trimmed == "" || trimmed[0] == '#' {
+			continue
+		}
+
+		var err error
+		switch cmd := trimmed[0]; {
+		case cmd == 't':
+			var t translation
+			t, err = parseTranslate(trimmed[1:])
+			stage = append(stage, t)
+		case cmd == '-' || (cmd >= '0' && cmd <= '9'):
+			var t translation
+			t, err = parseNumberPair(trimmed)
+			stage = append(stage, t)
+		case cmd == 'f':
+			// Freeze: start a new translation stage
+			if len(stage) > 0 {
+				f.stages = append(f.stages, stage)
+				stage = nil
+			}
+		case cmd == 'b':
+			f.mode, f.modeSet = ModeDBCS, true
+		case cmd == 'u':
+			f.mode, f.modeSet = ModeUTF8, true
+		case cmd == 'j':
+			f.mode, f.modeSet = ModeShiftJIS, true
+		case cmd == 'h':
+			f.mode, f.modeSet = ModeHZ, true
+		case cmd == 'g':
+			var c charsetCmd
+			c, err = parseCharset(trimmed[1:])
+			f.charsets = append(f.charsets, c)
+			f.mode, f.modeSet = ModeISO2022, true
+		default:
+			err = fmt.Errorf("unknown command %q", cmd)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: line %d: %w", ErrInvalidControlFile, lineNum, err)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error reading control file: %w", err)
+	}
+
+	if len(stage) > 0 {
+		f.stages = append(f.stages, stage)
+	}
+	return f, nil
+}
+
+// Mode returns the input mode selected by the file and whether one was set.
+func (f *File) Mode() (InputMode, bool) {
+	return f.mode, f.modeSet
+}
+
+// lineScanner walks a single command line.
+type lineScanner struct {
+	s   string
+	pos int
+}
+
+func (ls *lineScanner) skipSpace() {
+	for ls.pos < len(ls.s) && (ls.s[ls.pos] == ' ' || ls.s[ls.pos] == '\t') {
+		ls.pos++
+	}
+}
+
+func (ls *lineScanner) atEnd() bool {
+	return ls.pos >= len(ls.s)
+}
+
+func (ls *lineScanner) peek() byte {
+	if ls.atEnd() {
+		return 0
+	}
+	return ls.s[ls.pos]
+}
+
+// next returns the next character, decoding UTF-8 where valid and otherwise
+// treating the byte as Latin-1, so both legacy and UTF-8 control files work.
+func (ls *lineScanner) next() rune {
+	r, size := utf8.DecodeRuneInString(ls.s[ls.pos:])
+	if r == utf8.RuneError && size <= 1 {
+		r = rune(ls.s[ls.pos])
+		size = 1
+	}
+	ls.pos += size
+	return r
+}
+
+// readChar reads a character operand: a literal character or a backslash
+// escape ("\65", "\0x100", "\-2", "\n", "\ ", "\\" ...).
+func (ls *lineScanner) readChar() (rune, error) {
+	if ls.atEnd() {
+		return 0, errors.New("missing character")
+	}
+	r := ls.next()
+	if r != '\\' {
+		return r, nil
+	}
+	if ls.atEnd() {
+		return 0, errors.New("dangling backslash")
+	}
+
+	switch c := ls.peek(); {
+	case c == '-' || (c >= '0' && c <= '9'):
+		return ls.readNumber()
+	case c == 'a':
+		ls.pos++
+		return 7, nil
+	case c == 'b':
+		ls.pos++
+		return 8, nil
+	case c == 'e':
+		ls.pos++
+		return 27, nil
+	case c == 'f':
+		ls.pos++
+		return 12, nil
+	case c == 'n':
+		ls.pos++
+		return 10, nil
+	case c == 'r':
+		ls.pos++
+		return 13, nil
+	case c == 't':
+		ls.pos++
+		return 9, nil
+	case c == 'v':
+		ls.pos++
+		return 11, nil
+	default:
+		// "\ ", "\\" and any other escaped character stand for themselves
+		return ls.next(), nil
+	}
+}
+
+// readNumber reads a decimal, 0-prefixed octal or 0x-prefixed hexadecimal
+// number with an optional leading minus sign. It stops at the first character
+// that cannot belong to the number, so "\65-\90" reads as two numbers.
+func (ls *lineScanner) readNumber() (rune, error) {
+	start := ls.pos
+	negative := false
+	if ls.peek() == '-' {
+		negative = true
+		ls.pos++
+	}
+
+	base := 10
+	isDigit := func(c byte) bool { return c >= '0' && c <= '9' }
+	if strings.HasPrefix(ls.s[ls.pos:], "0x") || strings.HasPrefix(ls.s[ls.pos:], "0X") {
+		base = 16
+		ls.pos += 2
+		isDigit = func(c byte) bool {
+			return (c >= '0' && c <= '9') || (c >= 'a' && c <= 'f') || (c >= 'A' && c <= 'F')
+		}
+	} else if ls.peek() == '0' {
+		base = 8
+		isDigit = func(c byte) bool { return c >= '0' && c <= '7' }
+	}
+
+	digitsStart := ls.pos
+	for !ls.atEnd() && isDigit(ls.peek()) {
+		ls.pos++
+	}
+	val, err := strconv.ParseInt(ls.s[digitsStart:ls.pos], base, 32)
+	if err != nil {
+		return 0, fmt.Errorf("invalid number %q", ls.s[start:ls.pos])
+	}
+	if negative {
+		val = -val
+	}
+	return rune(val), nil
+}
+
+// readRange reads a character operand optionally followed by "-char".
+func (ls *lineScanner) readRange() (lo, hi rune, err error) {
+	lo, err = ls.readChar()
+	if err != nil {
+		return 0, 0, err
+	}
+	hi = lo
+	// A '-' directly after the operand (no whitespace) makes it a range
+	if ls.peek() == '-' && ls.pos+1 < len(ls.s) && ls.s[ls.pos+1] != ' ' && ls.s[ls.pos+1] != '\t' {
+		ls.pos++
+		if hi, err = ls.readChar(); err != nil {
+			return 0, 0, err
+		}
+	}
+	if hi < lo {
+		return 0, 0, fmt.Errorf("inverted range %d-%d", lo, hi)
+	}
+	return lo, hi, nil
+}
+
+// parseTranslate parses the operands of a "t" command.
+func parseTranslate(args string) (translation, error) {
+	ls := &lineScanner{s: args}
+	if !ls.atEnd() && ls.peek() != ' ' && ls.peek() != '\t' {
+		return translation{}, errors.New("expected whitespace after \"t\"")
+	}
+	ls.skipSpace()
+	inLo, inHi, err := ls.readRange()
+	if err != nil {
+		return translation{}, fmt.Errorf("t command input: %w", err)
+	}
+	ls.skipSpace()
+	outLo, outHi, err := ls.readRange()
+	if err != nil {
+		return translation{}, fmt.Errorf("t command output: %w", err)
+	}
+
+	// A single output character is only valid for a single input character;
+	// ranges must be the same size.
+	if outHi-outLo != inHi-inLo {
+		return translation{}, fmt.Errorf("t command ranges differ in size (%d vs %d)",
+			inHi-inLo+1, outHi-outLo+1)
+	}
+	return translation{lo: inLo, hi: inHi, offset: outLo - inLo}, nil
+}
+
+// parseNumberPair parses a "number number" line (Unicode mapping table form).
+func parseNumberPair(line string) (translation, error) {
+	ls := &lineScanner{s: line}
+	in, err := ls.readNumber()
+	if err != nil {
+		return translation{}, err
+	}
+	if ls.peek() != ' ' && ls.peek() != '\t' {
+		return translation{}, errors.New("expected whitespace between numbers")
+	}
+	ls.skipSpace()
+	out, err := ls.readNumber()
+	if err != nil {
+		return translation{}, err
+	}
+	return translation{lo: in, hi: in, offset: out - in}, nil
+}
+
+// parseCharset parses the operands of a "g" command:
+//
+//	g {0|1|2|3} {94|96|94x94} [char]
+//	g {L|R} {0|1|2|3}
+func parseCharset(args string) (charsetCmd, error) {
+	ls := &lineScanner{s: args}
+	ls.skipSpace()
+
+	switch c := ls.peek(); {
+	case c == 'L' || c == 'l' || c == 'R' || c == 'r':
+		ls.pos++
+		ls.skipSpace()
+		slot := ls.peek()
+		if slot < '0' || slot > '3' {
+			return charsetCmd{}, errors.New("g command: expected G0-G3 after L/R")
+		}
+		invoke := byte('L')
+		if c == 'R' || c == 'r' {
+			invoke = 'R'
+		}
+		return charsetCmd{invoke: invoke, slot: int(slot - '0')}, nil
+	case c >= '0' && c <= '3':
+		ls.pos++
+		cmd := charsetCmd{slot: int(c - '0')}
+		ls.skipSpace()
+
+		rest := ls.s[ls.pos:]
+		var size string
+		switch {
+		case strings.HasPrefix(rest, "94x94"):
+			size = "94x94"
+		case strings.HasPrefix(rest, "94"):
+			size = "94"
+		case strings.HasPrefix(rest, "96"):
+			size = "96"
+		default:
+			return charsetCmd{}, errors.New("g command: expected size 94, 96 or 94x94")
+		}
+		ls.pos += len(size)
+		ls.skipSpace()
+
+		var designator rune
+		if !ls.atEnd() {
+			d, err := ls.readChar()
+			if err != nil {
+				return charsetCmd{}, fmt.Errorf("g command: %w", err)
+			}
+			designator = d
+		}
+
+		switch size {
+		case "94x94":
+			cmd.code = designator << 16
+			cmd.double = true
+		case "94":
+			if designator != 'B' { // ASCII keeps its own codes
+				cmd.code = designator << 16
+			}
+		case "96":
+			cmd.code = 0x80
+			if designator != 'A' { // Latin-1 keeps its own codes
+				cmd.code |= designator << 16
+			}
+		}
+		return cmd, nil
+	default:
+		return charsetCmd{}, errors.New("g command: expected 0-3, L or R")
+	}
+}
@@ -0,0 +1,253 @@
+package control
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func mustParse(t *testing.T, src string) *File {
+	t.Helper()
+	f, err := Parse(strings.NewReader(src))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	return f
+}
+
+func TestParse_Translations(t *testing.T) {
+	tests := []struct {
+		name   string
+		src    string
+		stages [][]translation
+	}{
+		{
+			name:   "single character",
+			src:    "t a b\n",
+			stages: [][]translation{{{lo: 'a', hi: 'a', offset: 1}}},
+		},
+		{
+			name:   "range",
+			src:    "t a-z A-Z\n",
+			stages: [][]translation{{{lo: 'a', hi: 'z', offset: 'A' - 'a'}}},
+		},
+		{
+			name:   "numeric escapes",
+			src:    "t \\65 \\0x42\n",
+			stages: [][]translation{{{lo: 65, hi: 65, offset: 1}}},
+		},
+		{
+			name:   "octal escape",
+			src:    "t \\0101 B\n",
+			stages: [][]translation{{{lo: 65, hi: 65, offset: 1}}},
+		},
+		{
+			name:   "negative code",
+			src:    "t # \\-2\n",
+			stages: [][]translation{{{lo: '#', hi: '#', offset: -2 - '#'}}},
+		},
+		{
+			name:   "escaped range",
+			src:    "t \\65-\\90 \\97-\\122\n",
+			stages: [][]translation{{{lo: 65, hi: 90, offset: 32}}},
+		},
+		{
+			name:   "escaped space and backslash",
+			src:    "t \\  _\nt \\\\ /\n",
+			stages: [][]translation{{{lo: ' ', hi: ' ', offset: '_' - ' '}, {lo: '\\', hi: '\\', offset: '/' - '\\'}}},
+		},
+		{
+			name:   "UTF-8 literal",
+			src:    "t é e\n",
+			stages: [][]translation{{{lo: 'é', hi: 'é', offset: 'e' - 'é'}}},
+		},
+		{
+			name:   "number pair",
+			src:    "0xA1 0x0104\n",
+			stages: [][]translation{{{lo: 0xA1, hi: 0xA1, offset: 0x0104 - 0xA1}}},
+		},
+		{
+			name: "comments and blank lines",
+			src:  "flc2a\n# comment\n\n  # indented comment\nt a b\n",
+			stages: [][]translation{
+				{{lo: 'a', hi: 'a', offset: 1}},
+			},
+		},
+		{
+			name: "freeze starts a new stage",
+			src:  "t a b\nf\nt b c\n",
+			stages: [][]translation{
+				{{lo: 'a', hi: 'a', offset: 1}},
+				{{lo: 'b', hi: 'b', offset: 1}},
+			},
+		},
+		{
+			name:   "CRLF line endings",
+			src:    "t a b\r\n",
+			stages: [][]translation{{{lo: 'a', hi: 'a', offset: 1}}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := mustParse(t, tt.src)
+			if !reflect.DeepEqual(f.stages, tt.stages) {
+				t.Errorf("stages = %+v, want %+v", f.stages, tt.stages)
+			}
+		})
+	}
+}
+
+func TestParse_Modes(t *testing.T) {
+	tests := []struct {
+		src     string
+		want    InputMode
+		wantSet bool
+	}{
+		{"t a b\n", ModeISO2022, false},
+		{"b\n", ModeDBCS, true},
+		{"u\n", ModeUTF8, true},
+		{"j\n", ModeShiftJIS, true},
+		{"h\n", ModeHZ, true},
+		{"g 1 96 A\n", ModeISO2022, true},
+		{"u\nh\n", ModeHZ, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(strings.ReplaceAll(tt.src, "\n", ";"), func(t *testing.T) {
+			mode, set := mustParse(t, tt.src).Mode()
+			if mode != tt.want || set != tt.wantSet {
+				t.Errorf("Mode() = (%v, %v), want (%v, %v)", mode, set, tt.want, tt.wantSet)
+			}
+		})
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		src      string
+		wantLine string
+	}{
+		{"unknown command", "t a b\nx\n", "line 2"},
+		{"range size mismatch", "t a-z A\n", "line 1"},
+		{"inverted range", "# c\nt z-a A-Z\n", "line 2"},
+		{"missing output", "t a\n", "line 1"},
+		{"dangling backslash", "t a \\\n", "line 1"},
+		{"bad number pair", "65\n", "line 1"},
+		{"bad g command", "g 5 94\n", "line 1"},
+		{"bad g size", "g 0 95 B\n", "line 1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(strings.NewReader(tt.src))
+			if !errors.Is(err, ErrInvalidControlFile) {
+				t.Fatalf("Parse() error = %v, want ErrInvalidControlFile", err)
+			}
+			if !strings.Contains(err.Error(), tt.wantLine) {
+				t.Errorf("Parse() error = %q, want it to mention %q", err, tt.wantLine)
+			}
+		})
+	}
+}
+
+func TestDecoder_Apply(t *testing.T) {
+	tests := []struct {
+		name  string
+		files []string
+		input string
+		want  []rune
+	}{
+		{
+			name:  "no files is Latin-1",
+			input: "a\xe9",
+			want:  []rune{'a', 0xE9},
+		},
+		{
+			name:  "translation",
+			files: []string{"t a-z A-Z\n"},
+			input: "hi!",
+			want:  []rune{'H', 'I', '!'},
+		},
+		{
+			name:  "first match wins within a stage",
+			files: []string{"t a b\nt a c\n"},
+			input: "a",
+			want:  []rune{'b'},
+		},
+		{
+			name:  "stages run in sequence",
+			files: []string{"t a b\nf\nt b c\n"},
+			input: "ab",
+			want:  []rune{'c', 'c'},
+		},
+		{
+			name:  "chained files run in order",
+			files: []string{"t a b\n", "t b c\n"},
+			input: "a",
+			want:  []rune{'c'},
+		},
+		{
+			name:  "last mode wins",
+			files: []string{"b\n", "u\n"},
+			input: "é",
+			want:  []rune{'é'},
+		},
+		{
+			name:  "UTF-8 with invalid byte",
+			files: []string{"u\n"},
+			input: "a\xffb",
+			want:  []rune{'a', 128, 'b'},
+		},
+		{
+			name:  "DBCS",
+			files: []string{"b\n"},
+			input: "a\xb0\xa1",
+			want:  []rune{'a', 0xB0A1},
+		},
+		{
+			name:  "Shift-JIS",
+			files: []string{"j\n"},
+			input: "\x82\xa0\xa0",
+			want:  []rune{0x82A0, 0xA0},
+		},
+		{
+			name:  "HZ",
+			files: []string{"h\n"},
+			input: "a~{\x30\x21~}~~b",
+			want:  []rune{'a', 0x3021, '~', 'b'},
+		},
+		{
+			name:  "ISO 2022 96-character set",
+			files: []string{"g 1 96 B\n"},
+			input: "\xa1",
+			want:  []rune{'B'<<16 | 0x80 | 0x21},
+		},
+		{
+			name:  "ISO 2022 94x94 set via escape",
+			input: "\x1b$)A\x0f\x30\x21",
+			want:  []rune{'A'<<16 | 0x3021},
+		},
+		{
+			name:  "ISO 2022 single shift",
+			files: []string{"g 2 94 J\n"},
+			input: "\x8e!!",
+			want:  []rune{'J'<<16 | '!', '!'},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var files []*File
+			for _, src := range tt.files {
+				files = append(files, mustParse(t, src))
+			}
+			got := NewDecoder(files).Apply(tt.input)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Apply(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
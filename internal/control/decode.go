@@ -0,0 +1,281 @@
+package control
+
+import "unicode/utf8"
+
+// Decoder turns input text into FIGfont character codes using a chain of
+// control files. It is immutable and safe for concurrent use; per-call
+// decoding state lives on the stack of Apply.
+type Decoder struct {
+	stages [][]translation
+	iso    iso2022State
+	mode   InputMode
+}
+
+// iso2022State tracks the ISO 2022 character set designations and invocations.
+type iso2022State struct {
+	gn    [4]rune // Base code added to characters from G0-G3
+	dbl   [4]bool // Whether G0-G3 is a 94x94 (double-byte) set
+	left  int     // Set invoked into the left half (bytes 33-126)
+	right int     // Set invoked into the right half (bytes 160-255)
+}
+
+// defaultISO2022 is G0=ASCII, G1=Latin-1 top half, left=G0, right=G1.
+func defaultISO2022() iso2022State {
+	return iso2022State{gn: [4]rune{0, 0x80, 0, 0}, left: 0, right: 1}
+}
+
+// NewDecoder chains the given control files. Translation stages run in file
+// order; the input mode is taken from the last file that sets one, and "g"
+// commands are applied in order on top of the ISO 2022 defaults.
+func NewDecoder(files []*File) *Decoder {
+	d := &Decoder{iso: defaultISO2022(), mode: ModeISO2022}
+	for _, f := range files {
+		if f == nil {
+			continue
+		}
+		d.stages = append(d.stages, f.stages...)
+		if f.modeSet {
+			d.mode = f.mode
+		}
+		for _, c := range f.charsets {
+			switch c.invoke {
+			case 'L':
+				d.iso.left = c.slot
+			case 'R':
+				d.iso.right = c.slot
+			default:
+				d.iso.gn[c.slot] = c.code
+				d.iso.dbl[c.slot] = c.double
+			}
+		}
+	}
+	return d
+}
+
+// Apply decodes text according to the input mode and runs every decoded code
+// through the translation stages.
+func (d *Decoder) Apply(text string) []rune {
+	var codes []rune
+	switch d.mode {
+	case ModeUTF8:
+		codes = decodeUTF8(text)
+	case ModeDBCS:
+		codes = decodeDoubleByte(text, func(b byte) bool { return b >= 0x80 })
+	case ModeShiftJIS:
+		codes = decodeDoubleByte(text, func(b byte) bool {
+			return (b >= 0x80 && b <= 0x9F) || (b >= 0xE0 && b <= 0xEF)
+		})
+	case ModeHZ:
+		codes = decodeHZ(text)
+	default:
+		codes = d.decodeISO2022(text)
+	}
+
+	for i, r := range codes {
+		codes[i] = d.translate(r)
+	}
+	return codes
+}
+
+// translate runs a code through each stage. Within a stage only the first
+// matching translation applies; the result feeds the next stage.
+func (d *Decoder) translate(r rune) rune {
+	for _, stage := range d.stages {
+		for _, t := range stage {
+			if r >= t.lo && r <= t.hi {
+				r += t.offset
+				break
+			}
+		}
+	}
+	return r
+}
+
+// decodeUTF8 decodes UTF-8, mapping each malformed byte to code 128.
+func decodeUTF8(text string) []rune {
+	codes := make([]rune, 0, len(text))
+	for i := 0; i < len(text); {
+		r, size := utf8.DecodeRuneInString(text[i:])
+		if r == utf8.RuneError && size <= 1 {
+			r = invalidUTF8Code
+			size = 1
+		}
+		codes = append(codes, r)
+		i += size
+	}
+	return codes
+}
+
+// decodeDoubleByte combines a lead byte and the following byte into
+// lead*256+trail; all other bytes stand alone.
+func decodeDoubleByte(text string, isLead func(byte) bool) []rune {
+	codes := make([]rune, 0, len(text))
+	for i := 0; i < len(text); i++ {
+		b := text[i]
+		if isLead(b) && i+1 < len(text) {
+			codes = append(codes, rune(b)<<8|rune(text[i+1]))
+			i++
+			continue
+		}
+		codes = append(codes, rune(b))
+	}
+	return codes
+}
+
+// decodeHZ decodes HZ: "~{" and "~}" switch double-byte mode on and off,
+// "~~" is a literal tilde and any other "~x" pair is dropped.
+func decodeHZ(text string) []rune {
+	codes := make([]rune, 0, len(text))
+	double := false
+	for i := 0; i < len(text); i++ {
+		b := text[i]
+		if b == '~' && i+1 < len(text) {
+			i++
+			switch text[i] {
+			case '{':
+				double = true
+			case '}':
+				double = false
+			case '~':
+				codes = append(codes, '~')
+			}
+			continue
+		}
+		if double && i+1 < len(text) {
+			codes = append(codes, rune(b)<<8|rune(text[i+1]))
+			i++
+			continue
+		}
+		codes = append(codes, rune(b))
+	}
+	return codes
+}
+
+// decodeISO2022 decodes ISO 2022 escape sequences and shifts, mirroring the
+// FIGlet 2.2 reference implementation.
+func (d *Decoder) decodeISO2022(text string) []rune {
+	const esc = 27
+
+	st := d.iso
+	codes := make([]rune, 0, len(text))
+
+	// single holds the set for a single-shift (SS2/SS3), or -1
+	single := -1
+	for i := 0; i < len(text); i++ {
+		b := text[i]
+
+		switch {
+		case b == 14: // SO: left half from G0
+			st.left = 0
+			continue
+		case b == 15: // SI: left half from G1
+			st.left = 1
+			continue
+		case b == 142: // SS2
+			single = 2
+			continue
+		case b == 143: // SS3
+			single = 3
+			continue
+		case b == esc && i+1 < len(text):
+			if n := st.escape(text[i+1:], &single); n > 0 {
+				i += n
+				continue
+			}
+		}
+
+		left, right := st.left, st.right
+		if single >= 0 {
+			left, right = single, single
+			single = -1
+		}
+
+		switch {
+		case b >= 0x21 && b <= 0x7E:
+			if st.dbl[left] && i+1 < len(text) {
+				codes = append(codes, st.gn[left]|rune(b)<<8|rune(text[i+1]))
+				i++
+			} else {
+				codes = append(codes, st.gn[left]|rune(b))
+			}
+		case b >= 0xA0:
+			if st.dbl[right] && i+1 < len(text) {
+				codes = append(codes, st.gn[right]|rune(b)<<8|rune(text[i+1]))
+				i++
+			} else {
+				codes = append(codes, st.gn[right]|rune(b&^0x80))
+			}
+		default:
+			codes = append(codes, rune(b))
+		}
+	}
+	return codes
+}
+
+// escape applies the escape sequence following an ESC byte and returns how
+// many bytes after the ESC it consumed (0 if it is not a known sequence).
+func (st *iso2022State) escape(seq string, single *int) int {
+	switch seq[0] {
+	case 'N':
+		*single = 2
+		return 1
+	case 'O':
+		*single = 3
+		return 1
+	case 'n':
+		st.left = 2
+		return 1
+	case 'o':
+		st.left = 3
+		return 1
+	case '~':
+		st.right = 1
+		return 1
+	case '}':
+		st.right = 2
+		return 1
+	case '|':
+		st.right = 3
+		return 1
+	case '(', ')', '*', '+': // 94-character set into G0-G3
+		if len(seq) < 2 {
+			return 0
+		}
+		slot := int(seq[0] - '(')
+		st.gn[slot] = 0
+		if seq[1] != 'B' {
+			st.gn[slot] = rune(seq[1]) << 16
+		}
+		st.dbl[slot] = false
+		return 2
+	case '-', '.', '/': // 96-character set into G1-G3
+		if len(seq) < 2 {
+			return 0
+		}
+		slot := int(seq[0]-'-') + 1
+		st.gn[slot] = 0x80
+		if seq[1] != 'A' {
+			st.gn[slot] |= rune(seq[1]) << 16
+		}
+		st.dbl[slot] = false
+		return 2
+	case '$': // 94x94 character set
+		if len(seq) < 2 {
+			return 0
+		}
+		if seq[1] >= '(' && seq[1] <= '+' {
+			if len(seq) < 3 {
+				return 0
+			}
+			slot := int(seq[1] - '(')
+			st.gn[slot] = rune(seq[2]) << 16
+			st.dbl[slot] = true
+			return 3
+		}
+		// Deprecated "ESC $ <D>" form designates G0
+		st.gn[0] = rune(seq[1]) << 16
+		st.dbl[0] = true
+		return 2
+	}
+	return 0
+}
@@ -121,7 +121,7 @@ func isUnderscoreBorder(r rune) bool {
 }
 
 // hierarchyClass returns the hierarchy class for a rune, or -1 if not a hierarchy character.
-// Higher class = stronger. Class 0 is strongest ('|'), class 4 is weakest ('<>').
+// Characters of a higher class replace those of a lower one: '|' is class 0, '<>' class 5.
 func hierarchyClass(r rune) int {
 	switch r {
 	case '|':
@@ -149,10 +149,10 @@ func isHierarchySmush(lch, rch, result rune) bool {
 	}
 	lClass := hierarchyClass(lch)
 	rClass := hierarchyClass(rch)
-	// Result must match one of the input characters' class,
-	// and that character must be from a stronger (lower number) class than the other
-	return (resultClass == lClass && lClass < rClass) ||
-		(resultClass == rClass && rClass < lClass)
+	// As in FIGlet, the character from the later (higher numbered) class
+	// replaces the other
+	return (resultClass == lClass && lClass > rClass) ||
+		(resultClass == rClass && rClass > lClass)
 }
 
 // isPairSmush checks if the characters form an opposite pair.
@@ -200,7 +200,9 @@ func TestClassifySmushRule(t *testing.T) {
 		{"universal", 'A', 'B', 'B', 128, "universal"},
 		{"equal", 'X', 'X', 'X', 128 | 1, "equal"},
 		{"underscore", '_', '|', '|', 128 | 2, "underscore"},
-		{"hierarchy", '|', '/', '|', 128 | 4, "hierarchy"},
+		{"hierarchy", '|', '/', '/', 128 | 4, "hierarchy"},
+		{"hierarchy later class wins", ')', '[', ')', 128 | 4, "hierarchy"},
+		{"hierarchy earlier class", '|', '/', '|', 128 | 4, "unknown"},
 		{"pair", '[', ']', '|', 128 | 8, "pair"},
 		{"bigx slash", '/', '\\', '|', 128 | 16, "bigx"},
 		{"bigx backslash", '\\', '/', 'Y', 128 | 16, "bigx"},
@@ -32,7 +32,7 @@ type GlyphData struct {
 
 // SplitData contains information about a line split event.
 type SplitData struct {
-	Reason     string `json:"reason"` // "width", "wordbreak", "newline", "end"
+	Reason     string `json:"reason"` // "width", "wordbreak", "char", "newline", "end"
 	FSMPrev    int    `json:"fsm_prev"`
 	FSMNext    int    `json:"fsm_next"`
 	OutlineLen int    `json:"outline_len"`
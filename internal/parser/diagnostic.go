@@ -0,0 +1,182 @@
+package parser
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// Severity classifies a Diagnostic.
+type Severity int
+
+const (
+	// SeverityWarning marks a problem the parser recovered from
+	SeverityWarning Severity = iota
+	// SeverityError marks a problem that stops parsing
+	SeverityError
+)
+
+// String returns "warning" or "error".
+func (s Severity) String() string {
+	if s == SeverityError {
+		return "error"
+	}
+	return "warning"
+}
+
+// Kind is a machine-readable diagnostic category.
+type Kind string
+
+// Diagnostic kinds reported while parsing.
+const (
+	// KindInvalidHeader: missing or malformed signature, hardblank or header field
+	KindInvalidHeader Kind = "invalid-header"
+	// KindBaselineOutOfRange: Baseline outside 1..Height (clamped)
+	KindBaselineOutOfRange Kind = "baseline-out-of-range"
+	// KindInvalidFullLayout: FullLayout outside 0..32767 (ignored)
+	KindInvalidFullLayout Kind = "invalid-full-layout"
+	// KindLayoutMismatch: OldLayout and FullLayout disagree on the horizontal layout
+	KindLayoutMismatch Kind = "layout-mismatch"
+	// KindTruncatedComments: the file ends inside the comment block
+	KindTruncatedComments Kind = "truncated-comments"
+	// KindTruncatedFont: the file ends before all required ASCII characters
+	KindTruncatedFont Kind = "truncated-font"
+	// KindMissingDeutsch: the file ends before all seven Deutsch characters
+	KindMissingDeutsch Kind = "missing-deutsch"
+	// KindTruncatedGlyph: the file ends inside a code-tagged character
+	KindTruncatedGlyph Kind = "truncated-glyph"
+	// KindMaxLengthExceeded: a row is wider than the header's MaxLength
+	KindMaxLengthExceeded Kind = "maxlength-exceeded"
+	// KindInconsistentWidth: rows of one character differ in width (padded)
+	KindInconsistentWidth Kind = "inconsistent-width"
+	// KindInconsistentEndmark: rows of one character end with different endmarks
+	KindInconsistentEndmark Kind = "inconsistent-endmark"
+	// KindHardblankEndmark: a character uses the hardblank as its endmark (reported once)
+	KindHardblankEndmark Kind = "hardblank-endmark"
+	// KindInvalidCodeTag: a line where a code tag was expected is not one
+	KindInvalidCodeTag Kind = "invalid-code-tag"
+	// KindIllegalCode: a code-tagged character uses the illegal code -1
+	KindIllegalCode Kind = "illegal-code"
+	// KindDuplicateCode: the same code is tagged more than once
+	KindDuplicateCode Kind = "duplicate-code"
+	// KindCodetagCountMismatch: fewer code-tagged characters than Codetag_Count
+	KindCodetagCountMismatch Kind = "codetag-count-mismatch"
+	// KindTrailingData: non-blank lines follow the last declared character
+	KindTrailingData Kind = "trailing-data"
+	// KindReadError: the underlying reader failed or a line was too long
+	KindReadError Kind = "read-error"
+)
+
+// Diagnostic describes one problem found in a font file.
+type Diagnostic struct {
+	// Kind is the machine-readable category
+	Kind Kind
+	// Severity tells whether parsing recovered
+	Severity Severity
+	// Line is the 1-based line in the font file, or 0 if not tied to a line
+	Line int
+	// Code is the character code the problem belongs to, valid when HasCode is true
+	Code    rune
+	HasCode bool
+	// Message is a human-readable description
+	Message string
+}
+
+// String formats the diagnostic as "line N: char C: severity: message".
+func (d Diagnostic) String() string {
+	s := ""
+	if d.Line > 0 {
+		s += fmt.Sprintf("line %d: ", d.Line)
+	}
+	if d.HasCode {
+		s += fmt.Sprintf("char %d: ", d.Code)
+	}
+	return s + d.Severity.String() + ": " + d.Message
+}
+
+// Error is a fatal parse error. It carries the position and kind of the
+// problem and wraps the underlying error.
+type Error struct {
+	Diagnostic
+	Err error
+}
+
+// Error returns the message, prefixed with the line number when known.
+func (e *Error) Error() string {
+	if e.Line > 0 {
+		return fmt.Sprintf("line %d: %v", e.Line, e.Err)
+	}
+	return e.Err.Error()
+}
+
+// Unwrap returns the underlying error.
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// newError builds a fatal Error of the given kind at line.
+func newError(kind Kind, line int, err error) *Error {
+	return &Error{
+		Diagnostic: Diagnostic{Kind: kind, Severity: SeverityError, Line: line, Message: err.Error()},
+		Err:        err,
+	}
+}
+
+// warn records a recoverable problem on the font, both as a Diagnostic and
+// as a plain Warnings message.
+func (f *Font) warn(d Diagnostic) {
+	d.Severity = SeverityWarning
+	f.Diagnostics = append(f.Diagnostics, d)
+	f.Warnings = append(f.Warnings, d.Message)
+}
+
+// lineScanner is a bufio.Scanner that tracks the number of the line it last
+// returned, so problems can be reported with their position.
+type lineScanner struct {
+	*bufio.Scanner
+	line int
+}
+
+// newLineScanner wraps scanner, counting lines through its split function.
+func newLineScanner(scanner *bufio.Scanner) *lineScanner {
+	ls := &lineScanner{Scanner: scanner}
+	scanner.Split(func(data []byte, atEOF bool) (int, []byte, error) {
+		advance, token, err := bufio.ScanLines(data, atEOF)
+		if token != nil {
+			ls.line++
+		}
+		return advance, token, err
+	})
+	return ls
+}
+
+// warnGlyph records the problems parseGlyph found in the glyph for code.
+// The hardblank endmark warning is only recorded for the first character.
+func (f *Font) warnGlyph(code rune, issues []Diagnostic) {
+	for _, d := range issues {
+		if d.Kind == KindHardblankEndmark {
+			if f.hardblankEndmarkReported {
+				continue
+			}
+			f.hardblankEndmarkReported = true
+		}
+		d.Code = code
+		d.HasCode = true
+		f.warn(d)
+	}
+}
+
+// wrapGlyphError wraps a fatal glyph error with its position and kind. The
+// file ending inside a character is a truncated font, positioned at the last
+// line; anything else is a read error on the line after it.
+func wrapGlyphError(err error, scanner *lineScanner, code rune, context string) *Error {
+	kind, line := KindReadError, scanner.line+1
+	if errors.Is(err, io.ErrUnexpectedEOF) {
+		kind, line = KindTruncatedFont, scanner.line
+	}
+	e := newError(kind, line, fmt.Errorf("%s: %w", context, err))
+	e.Code = code
+	e.HasCode = true
+	return e
+}
@@ -0,0 +1,275 @@
+package parser
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// firstLines returns the first n lines of data, each terminated by a newline.
+func firstLines(data string, n int) string {
+	lines := strings.SplitAfter(data, "\n")
+	return strings.Join(lines[:n], "")
+}
+
+// dollarHardblank switches a GenerateFontWithDeutschChars font to a '$'
+// hardblank, so its '@' endmarks are not reported as hardblank endmarks.
+func dollarHardblank(data string) string {
+	return strings.Replace(data, "flf2a@", "flf2a$", 1)
+}
+
+// In fonts from GenerateFontWithDeutschChars the header is line 1, character c
+// starts on line 2+2*(c-32), and code-tagged characters start on line 206.
+func TestParse_Diagnostics(t *testing.T) {
+	full := dollarHardblank(GenerateFontWithDeutschChars())
+
+	tests := []struct {
+		name     string
+		input    string
+		wantKind Kind
+		wantLine int
+		wantCode rune
+		hasCode  bool
+	}{
+		{
+			name:     "baseline_out_of_range",
+			input:    strings.Replace(full, "flf2a$ 2 2 ", "flf2a$ 2 5 ", 1),
+			wantKind: KindBaselineOutOfRange,
+			wantLine: 1,
+		},
+		{
+			name:     "full_layout_out_of_range",
+			input:    dollarHardblank(codetagFontHeader("0 40000")),
+			wantKind: KindInvalidFullLayout,
+			wantLine: 1,
+		},
+		{
+			name:     "layout_mismatch",
+			input:    dollarHardblank(codetagFontHeader("0 129")),
+			wantKind: KindLayoutMismatch,
+			wantLine: 1,
+		},
+		{
+			name:     "truncated_font",
+			input:    firstLines(full, 5),
+			wantKind: KindTruncatedFont,
+			wantLine: 5,
+			wantCode: '"',
+			hasCode:  true,
+		},
+		{
+			name:     "missing_deutsch",
+			input:    firstLines(full, 191),
+			wantKind: KindMissingDeutsch,
+			wantLine: 191,
+			wantCode: 196,
+			hasCode:  true,
+		},
+		{
+			name:     "inconsistent_width",
+			input:    full + "161\nii@\ni@@\n",
+			wantKind: KindInconsistentWidth,
+			wantLine: 207,
+			wantCode: 161,
+			hasCode:  true,
+		},
+		{
+			name:     "maxlength_exceeded",
+			input:    full + "162\nxxxxxxxxxxxx@\nxxxxxxxxxxxx@@\n",
+			wantKind: KindMaxLengthExceeded,
+			wantLine: 207,
+			wantCode: 162,
+			hasCode:  true,
+		},
+		{
+			name:     "inconsistent_endmark",
+			input:    full + "161\ni@\ni##\n",
+			wantKind: KindInconsistentEndmark,
+			wantLine: 208,
+			wantCode: 161,
+			hasCode:  true,
+		},
+		{
+			name:     "hardblank_endmark_reported_once",
+			input:    GenerateFontWithDeutschChars(),
+			wantKind: KindHardblankEndmark,
+			wantLine: 2,
+			wantCode: ' ',
+			hasCode:  true,
+		},
+		{
+			name:     "invalid_code_tag",
+			input:    full + "not a code tag\n",
+			wantKind: KindInvalidCodeTag,
+			wantLine: 206,
+		},
+		{
+			name:     "truncated_glyph",
+			input:    full + "161\ni@\n",
+			wantKind: KindTruncatedGlyph,
+			wantLine: 206,
+			wantCode: 161,
+			hasCode:  true,
+		},
+		{
+			name:     "illegal_code",
+			input:    full + "-1\nx@\nx@@\n",
+			wantKind: KindIllegalCode,
+			wantLine: 206,
+			wantCode: -1,
+			hasCode:  true,
+		},
+		{
+			name:     "duplicate_code",
+			input:    full + "161\ni@\ni@@\n161\nj@\nj@@\n",
+			wantKind: KindDuplicateCode,
+			wantLine: 209,
+			wantCode: 161,
+			hasCode:  true,
+		},
+		{
+			name:     "codetag_count_mismatch",
+			input:    dollarHardblank(codetagFontHeader("0 64 2")) + "161\ni@\ni@@\n",
+			wantKind: KindCodetagCountMismatch,
+			wantLine: 208,
+		},
+		{
+			name:     "trailing_data",
+			input:    dollarHardblank(codetagFontHeader("0 64 1")) + "161\ni@\ni@@\n\ngarbage\n",
+			wantKind: KindTrailingData,
+			wantLine: 210,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			font, err := Parse(strings.NewReader(tt.input))
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			if len(font.Diagnostics) == 0 {
+				t.Fatalf("Parse() Diagnostics empty, want %s", tt.wantKind)
+			}
+			if tt.wantKind == KindHardblankEndmark && len(font.Diagnostics) != 1 {
+				t.Errorf("got %d diagnostics, want the hardblank endmark once: %v",
+					len(font.Diagnostics), font.Diagnostics)
+			}
+			if len(font.Diagnostics) != len(font.Warnings) {
+				t.Errorf("got %d diagnostics but %d warnings", len(font.Diagnostics), len(font.Warnings))
+			}
+
+			d := font.Diagnostics[0]
+			if d.Kind != tt.wantKind {
+				t.Errorf("Kind = %s, want %s (%v)", d.Kind, tt.wantKind, font.Diagnostics)
+			}
+			if d.Severity != SeverityWarning {
+				t.Errorf("Severity = %s, want warning", d.Severity)
+			}
+			if d.Line != tt.wantLine {
+				t.Errorf("Line = %d, want %d", d.Line, tt.wantLine)
+			}
+			if d.HasCode != tt.hasCode || d.Code != tt.wantCode {
+				t.Errorf("Code = %d (HasCode %v), want %d (HasCode %v)", d.Code, d.HasCode, tt.wantCode, tt.hasCode)
+			}
+		})
+	}
+}
+
+func TestParse_NoDiagnostics(t *testing.T) {
+	font, err := Parse(strings.NewReader(dollarHardblank(GenerateFontWithDeutschChars())))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if len(font.Diagnostics) != 0 {
+		t.Errorf("Diagnostics = %v, want none", font.Diagnostics)
+	}
+}
+
+func TestParse_FatalErrors(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       string
+		wantKind    Kind
+		wantLine    int
+		errContains string
+	}{
+		{
+			name:        "empty",
+			input:       "",
+			wantKind:    KindInvalidHeader,
+			errContains: "empty font data",
+		},
+		{
+			name:        "bad_signature_after_blank_lines",
+			input:       "\n\nflf2b@ 2 2 10 0 0\n",
+			wantKind:    KindInvalidHeader,
+			wantLine:    3,
+			errContains: "invalid signature",
+		},
+		{
+			name:        "insufficient_fields",
+			input:       "flf2a@ 2 2\n",
+			wantKind:    KindInvalidHeader,
+			wantLine:    1,
+			errContains: "insufficient header fields",
+		},
+		{
+			name:        "truncated_space",
+			input:       "flf2a$ 2 2 10 0 0\n $@\n",
+			wantKind:    KindTruncatedFont,
+			wantLine:    2,
+			errContains: "character 32 (space)",
+		},
+		{
+			name:        "truncated_comments",
+			input:       "flf2a@ 2 2 10 0 3\ncomment\n",
+			wantKind:    KindTruncatedComments,
+			wantLine:    2,
+			errContains: "expected 3 comment lines, got 1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(strings.NewReader(tt.input))
+			var pe *Error
+			if !errors.As(err, &pe) {
+				t.Fatalf("Parse() error = %v, want *Error", err)
+			}
+			if pe.Kind != tt.wantKind {
+				t.Errorf("Kind = %s, want %s", pe.Kind, tt.wantKind)
+			}
+			if pe.Severity != SeverityError {
+				t.Errorf("Severity = %s, want error", pe.Severity)
+			}
+			if pe.Line != tt.wantLine {
+				t.Errorf("Line = %d, want %d", pe.Line, tt.wantLine)
+			}
+			if !strings.Contains(err.Error(), tt.errContains) {
+				t.Errorf("Error() = %q, want it to contain %q", err, tt.errContains)
+			}
+		})
+	}
+}
+
+func TestOldLayoutAgrees(t *testing.T) {
+	tests := []struct {
+		oldLayout  int
+		fullLayout int
+		want       bool
+	}{
+		{oldLayout: -1, fullLayout: 0, want: true},
+		{oldLayout: 0, fullLayout: 64, want: true},
+		{oldLayout: 15, fullLayout: 24463, want: true}, // standard.flf
+		{oldLayout: 0, fullLayout: 128, want: true},    // universal smushing
+		{oldLayout: 0, fullLayout: 129, want: false},
+		{oldLayout: -1, fullLayout: 64, want: false},
+		{oldLayout: 3, fullLayout: 135, want: false},
+	}
+
+	for _, tt := range tests {
+		if got := oldLayoutAgrees(tt.oldLayout, tt.fullLayout); got != tt.want {
+			t.Errorf("oldLayoutAgrees(%d, %d) = %v, want %v", tt.oldLayout, tt.fullLayout, got, tt.want)
+		}
+	}
+}
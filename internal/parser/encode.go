@@ -0,0 +1,263 @@
+package parser
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	// endmarkCandidates are tried in order when choosing a glyph's endmark. The
+	// first one that no row of the glyph ends with is used, so stripping the
+	// endmarks on re-parse can never eat glyph content.
+	endmarkCandidates = "@#$%&*!~^+=|"
+	// latin1Limit is the first code written as a hex code tag
+	latin1Limit = 0x100
+)
+
+// ErrNotEncodable is returned by Encode, before anything is written, when the
+// font cannot be represented as a FIGfont that parses back unchanged.
+var ErrNotEncodable = errors.New("cannot encode font")
+
+// Encode writes font to w in FIGfont 2.0 (flf2a) format and returns the number
+// of bytes written.
+//
+// The output consists of:
+//   - A complete header: hardblank, height, baseline, max length, old layout,
+//     comment lines, print direction, full layout and codetag count
+//   - The comment lines (padded with blank lines up to CommentLines)
+//   - The required ASCII (32-126) and Deutsch characters, in order
+//   - Every other character as a code-tagged FIGcharacter, in ascending code order
+//
+// A partial font may leave off trailing required characters when it has no
+// code-tagged characters, and is written just as partial, so Parse(Encode(f))
+// returns the same characters as f. Any other missing required character
+// would be read back as an empty glyph, so it is an ErrNotEncodable error, as
+// is a missing space. When FullLayoutSet is false, the full layout is derived
+// from OldLayout.
+func Encode(w io.Writer, font *Font) (int64, error) {
+	if err := validateForEncoding(font); err != nil {
+		return 0, fmt.Errorf("%w: %w", ErrNotEncodable, err)
+	}
+
+	cw := &countingWriter{w: w}
+	bw := bufio.NewWriter(cw)
+
+	required := requiredCodes()
+	var extras []rune
+	for code := range font.Characters {
+		if !isRequiredCode(code) {
+			extras = append(extras, code)
+		}
+	}
+	slices.Sort(extras)
+
+	// Only write required characters up to the last one the font defines,
+	// unless code-tagged characters follow and the whole set must be present.
+	// Validation has made sure none are missing before that.
+	requiredCount := len(required)
+	if len(extras) == 0 {
+		for i, code := range required {
+			if _, ok := font.Characters[code]; ok {
+				requiredCount = i + 1
+			}
+		}
+	}
+
+	commentLines := max(font.CommentLines, len(font.Comments))
+	fullLayout := font.FullLayout
+	if !font.FullLayoutSet {
+		fullLayout = fullLayoutFromOldLayout(font.OldLayout)
+	}
+	fmt.Fprintf(bw, "%s%c %d %d %d %d %d %d %d %d\n",
+		SignatureFLF, font.Hardblank, font.Height, font.Baseline, font.MaxLength,
+		font.OldLayout, commentLines, font.PrintDirection, fullLayout, len(extras))
+
+	for i := range commentLines {
+		if i < len(font.Comments) {
+			bw.WriteString(font.Comments[i])
+		}
+		bw.WriteByte('\n')
+	}
+
+	for _, code := range required[:requiredCount] {
+		writeGlyph(bw, font.Characters[code], font.Height)
+	}
+
+	for _, code := range extras {
+		// Decimal for Latin-1 and negative codes, hex for the rest, as
+		// hand-made fonts conventionally do
+		if code < latin1Limit {
+			fmt.Fprintf(bw, "%d\n", code)
+		} else {
+			fmt.Fprintf(bw, "0x%04X\n", code)
+		}
+		writeGlyph(bw, font.Characters[code], font.Height)
+	}
+
+	err := bw.Flush()
+	return cw.n, err
+}
+
+// validateForEncoding checks that font can be written and parsed back unchanged.
+func validateForEncoding(font *Font) error {
+	if font == nil {
+		return errors.New("nil font")
+	}
+	if font.Height <= 0 {
+		return fmt.Errorf("height must be positive, got %d", font.Height)
+	}
+	if font.Baseline < 1 || font.Baseline > font.Height {
+		return fmt.Errorf("baseline must be between 1 and height (%d), got %d", font.Height, font.Baseline)
+	}
+	if font.MaxLength <= 0 {
+		return fmt.Errorf("maxlength must be positive, got %d", font.MaxLength)
+	}
+	if font.OldLayout < -1 || font.OldLayout > 63 {
+		return fmt.Errorf("old layout must be in range -1..63, got %d", font.OldLayout)
+	}
+	if font.PrintDirection != 0 && font.PrintDirection != 1 {
+		return fmt.Errorf("invalid print direction %d (must be 0 or 1)", font.PrintDirection)
+	}
+	hb := font.Hardblank
+	if hb == ' ' || hb == '\r' || hb == '\n' || hb == 0 || !utf8.ValidRune(hb) {
+		return fmt.Errorf("invalid hardblank %q", hb)
+	}
+	for i, comment := range font.Comments {
+		if strings.ContainsAny(comment, "\r\n") {
+			return fmt.Errorf("comment line %d contains a line break", i+1)
+		}
+	}
+	for code, glyph := range font.Characters {
+		if code == illegalCharCode {
+			return errors.New("character code -1 is illegal")
+		}
+		if len(glyph) != font.Height {
+			return fmt.Errorf("character %d has %d rows, want %d", code, len(glyph), font.Height)
+		}
+		for row, line := range glyph {
+			if strings.ContainsAny(line, "\r\n") {
+				return fmt.Errorf("character %d row %d contains a line break", code, row+1)
+			}
+		}
+	}
+	return validateRequiredCharacters(font)
+}
+
+// validateRequiredCharacters checks that the required characters the font
+// defines can be written without gaps. Parse reads a required character
+// written as an empty glyph as defined, so only trailing ones may be left
+// off, and only when no code-tagged characters follow.
+func validateRequiredCharacters(font *Font) error {
+	hasExtras := false
+	for code := range font.Characters {
+		if !isRequiredCode(code) {
+			hasExtras = true
+			break
+		}
+	}
+
+	missing, gap := rune(0), false
+	for _, code := range requiredCodes() {
+		_, ok := font.Characters[code]
+		switch {
+		case !ok && !gap:
+			missing, gap = code, true
+		case ok && gap:
+			return fmt.Errorf("required character %d is missing before character %d", missing, code)
+		}
+	}
+	switch {
+	case missing == ' ':
+		return errors.New("the space character is required")
+	case gap && hasExtras:
+		return fmt.Errorf("required character %d is missing before code-tagged characters", missing)
+	}
+	return nil
+}
+
+// writeGlyph writes one FIGcharacter, closing each row with an endmark and
+// the last row with two.
+func writeGlyph(bw *bufio.Writer, glyph []string, height int) {
+	endmark := chooseEndmark(glyph)
+	for row := range height {
+		if row < len(glyph) {
+			bw.WriteString(glyph[row])
+		}
+		bw.WriteRune(endmark)
+		if row == height-1 {
+			bw.WriteRune(endmark)
+		}
+		bw.WriteByte('\n')
+	}
+}
+
+// chooseEndmark picks an endmark that no row of the glyph ends with, trying
+// the usual endmarks first and then any printable ASCII character.
+func chooseEndmark(glyph []string) rune {
+	endsWith := func(r rune) bool {
+		return slices.ContainsFunc(glyph, func(line string) bool {
+			last, _ := utf8.DecodeLastRuneInString(line)
+			return last == r
+		})
+	}
+	for _, candidate := range endmarkCandidates {
+		if !endsWith(candidate) {
+			return candidate
+		}
+	}
+	for c := rune(firstNonSpaceASCII); c <= lastPrintableASCII; c++ {
+		if !endsWith(c) {
+			return c
+		}
+	}
+	// Only reachable for glyphs with more rows than printable ASCII characters
+	return '@'
+}
+
+// requiredCodes returns the required characters in file order.
+func requiredCodes() []rune {
+	codes := make([]rune, 0, lastPrintableASCII-' '+1+len(deutschChars))
+	for code := rune(' '); code <= lastPrintableASCII; code++ {
+		codes = append(codes, code)
+	}
+	return append(codes, deutschChars[:]...)
+}
+
+// isRequiredCode reports whether code is one of the required characters.
+func isRequiredCode(code rune) bool {
+	return (code >= ' ' && code <= lastPrintableASCII) || slices.Contains(deutschChars[:], code)
+}
+
+// fullLayoutFromOldLayout derives the horizontal FullLayout bits from OldLayout:
+// -1 is full width, 0 is fitting and positive values are smushing rules.
+func fullLayoutFromOldLayout(oldLayout int) int {
+	const (
+		horzFitting  = 64
+		horzSmushing = 128
+	)
+	switch {
+	case oldLayout < 0:
+		return 0
+	case oldLayout == 0:
+		return horzFitting
+	default:
+		return horzSmushing | oldLayout
+	}
+}
+
+// countingWriter counts the bytes written through it.
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (cw *countingWriter) Write(p []byte) (int, error) {
+	n, err := cw.w.Write(p)
+	cw.n += int64(n)
+	return n, err
+}
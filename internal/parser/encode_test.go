@@ -0,0 +1,179 @@
+package parser
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func mustParseString(t *testing.T, data string) *Font {
+	t.Helper()
+	font, err := Parse(strings.NewReader(data))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	return font
+}
+
+func encodeString(t *testing.T, font *Font) string {
+	t.Helper()
+	var buf bytes.Buffer
+	n, err := Encode(&buf, font)
+	if err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	if n != int64(buf.Len()) {
+		t.Errorf("Encode() returned %d bytes, wrote %d", n, buf.Len())
+	}
+	return buf.String()
+}
+
+func TestEncode_RoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+	}{
+		{
+			name: "required characters only",
+			data: GenerateFontWithDeutschChars(),
+		},
+		{
+			name: "code-tagged characters",
+			data: codetagFontHeader("0 0 3") +
+				"161  INVERTED EXCLAMATION MARK\ni@\ni@@\n" +
+				"0x20AC EURO SIGN\nE@\nE@@\n" +
+				"-2 translation table entry\nT@\nT@@\n",
+		},
+		{
+			name: "comments and full layout",
+			data: strings.Replace(GenerateFontWithDeutschChars(),
+				"flf2a@ 2 2 10 0 0\n", "flf2a@ 2 2 10 15 2 1 24463\nfirst comment\n  second comment  \n", 1),
+		},
+		{
+			name: "partial font",
+			data: "flf2a$ 2 1 4 -1 0\n$$@\n$$@@\nab@\nab@@\n",
+		},
+		{
+			name: "TOIlet font",
+			data: generateTLFFont("0x2588 FULL BLOCK\n██│\n██││\n"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			original := mustParseString(t, tt.data)
+			encoded := encodeString(t, original)
+			decoded := mustParseString(t, encoded)
+
+			if !reflect.DeepEqual(decoded.Characters, original.Characters) {
+				t.Errorf("Characters differ after round trip\nencoded:\n%s", encoded)
+			}
+			if !reflect.DeepEqual(decoded.Comments, original.Comments) {
+				t.Errorf("Comments = %q, want %q", decoded.Comments, original.Comments)
+			}
+			if decoded.Hardblank != original.Hardblank || decoded.Height != original.Height ||
+				decoded.Baseline != original.Baseline || decoded.MaxLength != original.MaxLength ||
+				decoded.OldLayout != original.OldLayout || decoded.PrintDirection != original.PrintDirection {
+				t.Errorf("header fields differ after round trip: got %q", strings.SplitN(encoded, "\n", 2)[0])
+			}
+			if len(decoded.Warnings) != len(original.Warnings) {
+				t.Errorf("Warnings = %v, want %v", decoded.Warnings, original.Warnings)
+			}
+		})
+	}
+}
+
+func TestEncode_Header(t *testing.T) {
+	tests := []struct {
+		name       string
+		data       string
+		wantHeader string
+	}{
+		{
+			name:       "full layout derived from old layout",
+			data:       codetagFontHeader(""),
+			wantHeader: "flf2a@ 2 2 10 0 0 0 64 0",
+		},
+		{
+			name:       "full layout kept",
+			data:       codetagFontHeader("1 16384 1") + "256\nA@\nA@@\n",
+			wantHeader: "flf2a@ 2 2 10 0 0 1 16384 1",
+		},
+		{
+			name:       "TOIlet font written as flf2a",
+			data:       generateTLFFont(""),
+			wantHeader: "flf2a♠ 2 1 8 -1 1 0 0 0",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			encoded := encodeString(t, mustParseString(t, tt.data))
+			if header, _, _ := strings.Cut(encoded, "\n"); header != tt.wantHeader {
+				t.Errorf("header = %q, want %q", header, tt.wantHeader)
+			}
+		})
+	}
+}
+
+func TestEncode_EndmarkAvoidsGlyphContent(t *testing.T) {
+	font := mustParseString(t, GenerateFontWithDeutschChars())
+	font.Characters['A'] = []string{"a@", "b#"}
+	font.Characters['B'] = []string{"@@", "#$"}
+
+	decoded := mustParseString(t, encodeString(t, font))
+	ValidateChar(t, decoded, 'A', []string{"a@", "b#"}, "A")
+	ValidateChar(t, decoded, 'B', []string{"@@", "#$"}, "B")
+}
+
+func TestEncode_Errors(t *testing.T) {
+	valid := func() *Font {
+		return &Font{
+			Hardblank:  '$',
+			Height:     1,
+			Baseline:   1,
+			MaxLength:  2,
+			Characters: map[rune][]string{' ': {" "}},
+		}
+	}
+
+	tests := []struct {
+		name   string
+		modify func(f *Font)
+	}{
+		{"zero height", func(f *Font) { f.Height = 0 }},
+		{"baseline out of range", func(f *Font) { f.Baseline = 2 }},
+		{"zero max length", func(f *Font) { f.MaxLength = 0 }},
+		{"old layout out of range", func(f *Font) { f.OldLayout = 64 }},
+		{"bad print direction", func(f *Font) { f.PrintDirection = 2 }},
+		{"space hardblank", func(f *Font) { f.Hardblank = ' ' }},
+		{"comment with newline", func(f *Font) { f.Comments = []string{"a\nb"} }},
+		{"illegal code", func(f *Font) { f.Characters[-1] = []string{"x"} }},
+		{"wrong row count", func(f *Font) { f.Characters['A'] = []string{"x", "y"} }},
+		{"row with newline", func(f *Font) { f.Characters['A'] = []string{"x\n"} }},
+		{"missing space", func(f *Font) { f.Characters = map[rune][]string{'!': {"!"}} }},
+		{"no characters", func(f *Font) { f.Characters = map[rune][]string{} }},
+		{"gap in required characters", func(f *Font) { f.Characters['"'] = []string{"x"} }},
+		{"missing required before code tags", func(f *Font) { f.Characters[0x263A] = []string{"x"} }},
+	}
+
+	if _, err := Encode(&bytes.Buffer{}, valid()); err != nil {
+		t.Fatalf("Encode() of valid font error = %v", err)
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := valid()
+			tt.modify(f)
+			var buf bytes.Buffer
+			_, err := Encode(&buf, f)
+			if !errors.Is(err, ErrNotEncodable) {
+				t.Fatalf("Encode() error = %v, want ErrNotEncodable", err)
+			}
+			if buf.Len() != 0 {
+				t.Errorf("Encode() wrote %d bytes before failing", buf.Len())
+			}
+		})
+	}
+}
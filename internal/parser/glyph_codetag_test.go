@@ -0,0 +1,197 @@
+package parser
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// codetagFontHeader builds a 2-row font containing all 102 required characters,
+// with the given extra header fields appended (e.g. "0 0 0 2" for a Codetag_Count of 2).
+func codetagFontHeader(extraFields string) string {
+	data := GenerateFontWithDeutschChars()
+	if extraFields == "" {
+		return data
+	}
+	return strings.Replace(data, "flf2a@ 2 2 10 0 0\n", "flf2a@ 2 2 10 0 0 "+extraFields+"\n", 1)
+}
+
+// TestParseGlyphs_CodeTagged tests parsing of code-tagged FIGcharacters that
+// follow the required set, as specified in the FIGfont v2 spec ("CODE TAGGED
+// FIGCHARACTERS").
+func TestParseGlyphs_CodeTagged(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		validate func(t *testing.T, f *Font)
+	}{
+		{
+			name:  "decimal_octal_and_hex_codes",
+			input: codetagFontHeader("") + "161  INVERTED EXCLAMATION MARK\ni@\ni@@\n0242 octal cent\nc@\nc@@\n0x3A9  GREEK CAPITAL LETTER OMEGA\nO@\nO@@\n0X20AC\nE@\nE@@\n",
+			validate: func(t *testing.T, f *Font) {
+				ValidateCharCount(t, f, 106)
+				ValidateChar(t, f, 161, []string{"i", "i"}, "inverted exclamation")
+				ValidateChar(t, f, 0242, []string{"c", "c"}, "cent")
+				ValidateChar(t, f, 0x3A9, []string{"O", "O"}, "omega")
+				ValidateChar(t, f, 0x20AC, []string{"E", "E"}, "euro")
+			},
+		},
+		{
+			name:  "codetag_count_limits_parsing",
+			input: codetagFontHeader("0 0 1") + "161\ni@\ni@@\n162\nc@\nc@@\n",
+			validate: func(t *testing.T, f *Font) {
+				ValidateCharCount(t, f, 103)
+				ValidateCharExists(t, f, 161, "inverted exclamation")
+				if _, exists := f.Characters[162]; exists {
+					t.Error("character 162 beyond Codetag_Count should not be parsed")
+				}
+			},
+		},
+		{
+			name:  "zero_codetag_count_reads_none",
+			input: codetagFontHeader("0 0 0") + "161\ni@\ni@@\n",
+			validate: func(t *testing.T, f *Font) {
+				ValidateCharCount(t, f, 102)
+			},
+		},
+		{
+			name:  "codetag_count_larger_than_available",
+			input: codetagFontHeader("0 0 5") + "161\ni@\ni@@\n",
+			validate: func(t *testing.T, f *Font) {
+				ValidateCharCount(t, f, 103)
+				if len(f.Warnings) == 0 {
+					t.Error("expected a warning about missing code-tagged characters")
+				}
+			},
+		},
+		{
+			name:  "duplicate_code_last_wins",
+			input: codetagFontHeader("") + "196  LATIN CAPITAL LETTER A WITH DIAERESIS\nA@\nA@@\n",
+			validate: func(t *testing.T, f *Font) {
+				ValidateCharCount(t, f, 102)
+				ValidateChar(t, f, 196, []string{"A", "A"}, "A umlaut")
+			},
+		},
+		{
+			name:  "blank_and_invalid_lines_skipped",
+			input: codetagFontHeader("") + "\nnot a code tag\n161\ni@\ni@@\n",
+			validate: func(t *testing.T, f *Font) {
+				ValidateCharCount(t, f, 103)
+				ValidateCharExists(t, f, 161, "inverted exclamation")
+			},
+		},
+		{
+			name:  "truncated_codetagged_glyph",
+			input: codetagFontHeader("") + "161\ni@\n",
+			validate: func(t *testing.T, f *Font) {
+				ValidateCharCount(t, f, 102)
+			},
+		},
+		{
+			name:  "negative_codes_retained",
+			input: codetagFontHeader("") + "-0x0005  translation table\nt@\nt@@\n-2\nm@\nm@@\n161\ni@\ni@@\n",
+			validate: func(t *testing.T, f *Font) {
+				ValidateCharCount(t, f, 105)
+				ValidateChar(t, f, -5, []string{"t", "t"}, "translation table")
+				ValidateChar(t, f, -2, []string{"m", "m"}, "negative code")
+				ValidateCharExists(t, f, 161, "inverted exclamation")
+			},
+		},
+		{
+			name:  "code_minus_one_skipped",
+			input: codetagFontHeader("") + "-1  illegal\nx@\nx@@\n161\ni@\ni@@\n",
+			validate: func(t *testing.T, f *Font) {
+				ValidateCharCount(t, f, 103)
+				if _, exists := f.Characters[-1]; exists {
+					t.Error("character code -1 is illegal and should not be stored")
+				}
+				ValidateCharExists(t, f, 161, "inverted exclamation")
+			},
+		},
+		{
+			name:  "missing_character_code_zero",
+			input: codetagFontHeader("") + "0  missing character\n?@\n?@@\n",
+			validate: func(t *testing.T, f *Font) {
+				ValidateChar(t, f, MissingCharCode, []string{"?", "?"}, "missing character")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := Parse(strings.NewReader(tt.input))
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			tt.validate(t, f)
+		})
+	}
+}
+
+func TestParseCodeTag(t *testing.T) {
+	tests := []struct {
+		line   string
+		want   rune
+		wantOK bool
+	}{
+		{"161  INVERTED EXCLAMATION MARK", 161, true},
+		{"0xA1", 0xA1, true},
+		{"0XA1", 0xA1, true},
+		{"0241", 0241, true},
+		{"0", 0, true},
+		{"-2  missing", -2, true},
+		{"-0x0005", -5, true},
+		{"-010", -8, true},
+		{"0x7FFFFFFF", 0x7FFFFFFF, true},
+		{"-0x80000000", -0x80000000, true},
+		{"0x80000000", 0, false},
+		{"", 0, false},
+		{"   ", 0, false},
+		{"0x", 0, false},
+		{"089", 0, false},
+		{"--5", 0, false},
+		{"abc", 0, false},
+		{"12abc", 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.line, func(t *testing.T) {
+			got, ok := parseCodeTag(tt.line)
+			if ok != tt.wantOK || got != tt.want {
+				t.Errorf("parseCodeTag(%q) = (%d, %v), want (%d, %v)", tt.line, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestParse_BundledFontsCodeTagged(t *testing.T) {
+	tests := []struct {
+		font  string
+		chars []rune
+	}{
+		{"standard.flf", []rune{160, 169, 255, 0x0100, 0x017F}},
+		{"big.flf", []rune{169, 0x0391, 0x03C9, 0x03D6}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.font, func(t *testing.T) {
+			file, err := os.Open(filepath.Join("..", "..", "fonts", tt.font))
+			if err != nil {
+				t.Fatalf("failed to open font: %v", err)
+			}
+			defer file.Close()
+
+			f, err := Parse(file)
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			for _, r := range tt.chars {
+				glyph := ValidateCharExists(t, f, r, string(r))
+				if len(glyph) != f.Height {
+					t.Errorf("character U+%04X has %d rows, want %d", r, len(glyph), f.Height)
+				}
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package parser
 
 import (
+	"reflect"
 	"strings"
 	"testing"
 )
@@ -143,3 +144,41 @@ func TestGlyphBoundaryConditions(t *testing.T) {
 		})
 	}
 }
+
+func TestNormalizeGlyph(t *testing.T) {
+	tests := []struct {
+		name    string
+		rows    []string
+		height  int
+		want    []string
+		wantErr string
+	}{
+		{name: "unchanged", rows: []string{"ab", "cd"}, height: 2, want: []string{"ab", "cd"}},
+		{name: "pads short rows", rows: []string{"abc", "", "d"}, height: 3, want: []string{"abc", "   ", "d  "}},
+		{name: "pads by runes", rows: []string{"▄▀▄", "█"}, height: 2, want: []string{"▄▀▄", "█  "}},
+		{name: "too few rows", rows: []string{"a"}, height: 2, wantErr: "expected 2 rows, got 1"},
+		{name: "too many rows", rows: []string{"a", "b"}, height: 1, wantErr: "expected 1 rows, got 2"},
+		{name: "line break", rows: []string{"a\r"}, height: 1, wantErr: "row 1 contains a line break"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NormalizeGlyph(tt.rows, tt.height)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("NormalizeGlyph() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("NormalizeGlyph() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NormalizeGlyph() = %q, want %q", got, tt.want)
+			}
+			if len(tt.rows) > 0 && &got[0] == &tt.rows[0] {
+				t.Error("NormalizeGlyph() must return a copy")
+			}
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+)
+
+// generateTLFFont builds a 2-row TOIlet font with a multi-byte hardblank ('♠'),
+// UTF-8 glyph rows, a multi-byte endmark ('│') and the given code-tagged
+// characters appended after the required set. Codetag_Count is 0, as in many
+// TOIlet fonts, and must not stop the code-tagged characters being read.
+func generateTLFFont(codeTagged string) string {
+	var sb strings.Builder
+	sb.WriteString("tlf2a♠ 2 1 8 -1 1 0 0 0\n")
+	sb.WriteString("TOIlet test font\n")
+
+	for i := 32; i <= 126; i++ {
+		switch i {
+		case ' ':
+			sb.WriteString("♠│\n♠││\n")
+		case 'A':
+			sb.WriteString("▄▀▄│\n█▀█││\n")
+		default:
+			sb.WriteString("▀│\n▀││\n")
+		}
+	}
+	for range 7 {
+		sb.WriteString("ü│\nü││\n")
+	}
+	sb.WriteString(codeTagged)
+	return sb.String()
+}
+
+func TestParse_TLF(t *testing.T) {
+	font, err := Parse(strings.NewReader(generateTLFFont(
+		"0x2588  FULL BLOCK\n██│\n██││\n" +
+			"9731 SNOWMAN\n☃│\n☃││\n")))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	if font.Signature != SignatureTLF {
+		t.Errorf("Signature = %q, want %q", font.Signature, SignatureTLF)
+	}
+	if font.Hardblank != '♠' {
+		t.Errorf("Hardblank = %q, want %q", font.Hardblank, '♠')
+	}
+	if len(font.Comments) != 1 || font.Comments[0] != "TOIlet test font" {
+		t.Errorf("Comments = %q, want [\"TOIlet test font\"]", font.Comments)
+	}
+
+	ValidateCharCount(t, font, 104)
+	ValidateSpace(t, font, []string{"♠", "♠"})
+	ValidateChar(t, font, 'A', []string{"▄▀▄", "█▀█"}, "A")
+	ValidateChar(t, font, 'Ä', []string{"ü", "ü"}, "Ä")
+	ValidateChar(t, font, 0x2588, []string{"██", "██"}, "full block")
+	ValidateChar(t, font, '☃', []string{"☃", "☃"}, "snowman")
+
+	if len(font.Warnings) != 0 {
+		t.Errorf("unexpected warnings: %v", font.Warnings)
+	}
+}
+
+func TestParseSignature(t *testing.T) {
+	tests := []struct {
+		header  string
+		wantSig string
+		wantErr bool
+	}{
+		{header: "flf2a$ 1 1 1 0 0", wantSig: SignatureFLF},
+		{header: "tlf2a$ 1 1 1 0 0", wantSig: SignatureTLF},
+		{header: "tlf2a♠ 1 1 1 0 0", wantSig: SignatureTLF},
+		{header: "tlf2 $ 1 1 1 0 0", wantErr: true},
+		{header: "xlf2a$ 1 1 1 0 0", wantErr: true},
+		{header: "TLF2A$ 1 1 1 0 0", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.header, func(t *testing.T) {
+			font := &Font{}
+			err := parseSignature(tt.header, font)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("parseSignature(%q) expected error", tt.header)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseSignature(%q) error = %v", tt.header, err)
+			}
+			if font.Signature != tt.wantSig {
+				t.Errorf("Signature = %q, want %q", font.Signature, tt.wantSig)
+			}
+		})
+	}
+}
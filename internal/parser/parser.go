@@ -1,8 +1,7 @@
-// Package parser implements FIGfont (FLF 2.0) parsing.
+// Package parser implements FIGfont (FLF 2.0) and TOIlet font (TLF 2.0) parsing.
 package parser
 
 import (
-	"bufio"
 	"errors"
 	"fmt"
 	"io"
@@ -17,6 +16,8 @@ const (
 	minHeaderFields = 5
 	// minSignatureRunes is the minimum number of runes in a valid signature (handles UTF-8)
 	minSignatureRunes = 6
+	// maxFullLayout is the largest valid FullLayout value (15 bits)
+	maxFullLayout = 32767
 	// firstNonSpaceASCII is the first non-space printable ASCII character (!)
 	firstNonSpaceASCII = 33
 	// lastPrintableASCII is the last printable ASCII character (~)
@@ -28,8 +29,24 @@ const (
 
 	// ASCII threshold for fast-path optimization
 	asciiThreshold = 0x80
+
+	// SignatureFLF is the signature of a FIGfont (.flf) file
+	SignatureFLF = "flf2a"
+	// SignatureTLF is the signature of a TOIlet font (.tlf) file. TOIlet fonts
+	// share the FIGfont layout but are always UTF-8 encoded.
+	SignatureTLF = "tlf2a"
+
+	// MissingCharCode is the code of a FIGfont's "missing character", printed
+	// in place of any character the font does not define
+	MissingCharCode = 0
+	// illegalCharCode is the one character code the spec forbids in code tags
+	illegalCharCode = -1
 )
 
+// deutschChars are the 7 required German/Deutsch characters, in file order.
+// Per FIGfont spec: 196 (Ä), 214 (Ö), 220 (Ü), 228 (ä), 246 (ö), 252 (ü), 223 (ß)
+var deutschChars = [...]rune{196, 214, 220, 228, 246, 252, 223}
+
 // GlyphTrim contains precomputed trim information for a glyph row
 type GlyphTrim struct {
 	LeftmostVisible  int // Index of leftmost non-space character (-1 if all spaces)
@@ -121,7 +138,9 @@ func (f *Font) GetCharacterTrims(r rune) ([]GlyphTrim, bool) {
 
 // Font represents a parsed FIGfont with all its metadata and character glyphs.
 type Font struct {
-	// Characters maps ASCII codes to their glyph representations
+	// Characters maps character codes to their glyph representations.
+	// Code-tagged glyphs may use negative codes (except -1), and code 0 is the
+	// font's "missing character" when present.
 	Characters map[rune][]string
 
 	// CharacterTrims maps ASCII characters to their precomputed trim data per row
@@ -135,7 +154,7 @@ type Font struct {
 	// Comments contains the font comments
 	Comments []string
 
-	// Signature contains the FIGfont signature (e.g., "flf2a")
+	// Signature contains the font signature: "flf2a" or, for TOIlet fonts, "tlf2a"
 	Signature string
 
 	// Hardblank is the character used for hard blanks
@@ -168,11 +187,25 @@ type Font struct {
 	// CodetagCount specifies the number of code-tagged characters
 	CodetagCount int
 
-	// Warnings contains any non-fatal issues encountered during parsing
+	// CodetagCountSet indicates whether CodetagCount was present in the header
+	CodetagCountSet bool
+
+	// Warnings contains the messages of the warning Diagnostics, in order
 	Warnings []string
+
+	// Diagnostics contains the non-fatal issues encountered during parsing,
+	// with their line numbers, character codes and kinds
+	Diagnostics []Diagnostic
+
+	// hardblankEndmarkReported limits the hardblank endmark warning to the
+	// first character that uses it
+	hardblankEndmarkReported bool
 }
 
 // Parse reads a FIGfont from the provided reader and returns a parsed Font.
+//
+// Recoverable problems are recorded in Font.Diagnostics. Fatal problems are
+// returned as an *Error carrying the line number and kind.
 func Parse(r io.Reader) (*Font, error) {
 	// Use pooled scanner with pooled buffer
 	scanner, buf := createPooledScanner(r)
@@ -203,49 +236,51 @@ func ParseHeader(r io.Reader) (*Font, error) {
 }
 
 // parseHeaderWithScanner parses the header using an existing scanner
-func parseHeaderWithScanner(scanner *bufio.Scanner) (*Font, error) {
+func parseHeaderWithScanner(scanner *lineScanner) (*Font, error) {
 	// Read and validate header line
 	headerLine, err := readHeaderLine(scanner)
 	if err != nil {
 		return nil, err
 	}
+	headerLineNum := scanner.line
 
 	// Parse header into font structure
 	font := &Font{}
 
 	// Extract signature and hardblank
 	if err := parseSignature(headerLine, font); err != nil {
-		return nil, err
+		return nil, newError(KindInvalidHeader, headerLineNum, err)
 	}
 
 	// Parse numeric fields - need to skip past the hardblank character properly
 	// Convert to runes to handle multi-byte characters correctly
 	//
 	// Why rune-based parsing is critical here:
-	// The header format is: "flf2a<hardblank> <height> <baseline> ..."
+	// The header format is: "flf2a<hardblank> <height> <baseline> ..." (or "tlf2a...")
 	// If hardblank is multi-byte (e.g., '♠' = 3 bytes in UTF-8), byte-based
 	// indexing would put us in the middle of the character, causing parsing
 	// failures or data corruption. Rune-based indexing ensures we skip exactly
 	// 6 characters regardless of their byte representation.
 	runes := []rune(headerLine)
 	if len(runes) < minSignatureRunes {
-		return nil, fmt.Errorf("header line too short")
+		return nil, newError(KindInvalidHeader, headerLineNum, errors.New("header line too short"))
 	}
-	// Skip "flf2a" (5 runes) and hardblank (1 rune) = 6 runes total
+	// Skip "flf2a"/"tlf2a" (5 runes) and hardblank (1 rune) = 6 runes total
 	remainingHeader := string(runes[minSignatureRunes:])
 	fields := strings.Fields(remainingHeader)
 	if len(fields) < minHeaderFields {
-		return nil, fmt.Errorf("insufficient header fields: got %d, need at least %d", len(fields), minHeaderFields)
+		return nil, newError(KindInvalidHeader, headerLineNum,
+			fmt.Errorf("insufficient header fields: got %d, need at least %d", len(fields), minHeaderFields))
 	}
 
 	// Parse required fields
-	if err := parseRequiredFields(fields, font); err != nil {
-		return nil, err
+	if err := parseRequiredFields(fields, font, headerLineNum); err != nil {
+		return nil, newError(KindInvalidHeader, headerLineNum, err)
 	}
 
 	// Parse optional fields
-	if err := parseOptionalFields(fields, font); err != nil {
-		return nil, err
+	if err := parseOptionalFields(fields, font, headerLineNum); err != nil {
+		return nil, newError(KindInvalidHeader, headerLineNum, err)
 	}
 
 	// Read comment lines
@@ -262,12 +297,12 @@ func parseHeaderWithScanner(scanner *bufio.Scanner) (*Font, error) {
 }
 
 // readHeaderLine reads the first non-empty line from the scanner
-func readHeaderLine(scanner *bufio.Scanner) (string, error) {
+func readHeaderLine(scanner *lineScanner) (string, error) {
 	if !scanner.Scan() {
 		if err := scanner.Err(); err != nil {
-			return "", fmt.Errorf("error reading header: %w", err)
+			return "", newError(KindReadError, scanner.line+1, fmt.Errorf("error reading header: %w", err))
 		}
-		return "", fmt.Errorf("empty font data")
+		return "", newError(KindInvalidHeader, 0, errors.New("empty font data"))
 	}
 
 	headerLine := strings.TrimSpace(scanner.Text())
@@ -285,7 +320,7 @@ func readHeaderLine(scanner *bufio.Scanner) (string, error) {
 			}
 		}
 		if headerLine == "" {
-			return "", fmt.Errorf("empty font data")
+			return "", newError(KindInvalidHeader, 0, errors.New("empty font data"))
 		}
 	}
 
@@ -298,6 +333,11 @@ func readHeaderLine(scanner *bufio.Scanner) (string, error) {
 // by the hardblank character (no space between them). The hardblank can be any
 // non-whitespace character, including multi-byte UTF-8 characters.
 //
+// TOIlet fonts use the signature "tlf2a" and are otherwise laid out the same way,
+// so both signatures are accepted and the rest of the file is parsed identically.
+// TOIlet glyph rows are UTF-8, which the rune-aware endmark stripping and width
+// calculation already handle.
+//
 // We use rune-based parsing here because:
 // 1. The hardblank might be a multi-byte UTF-8 character (e.g., '♠' = 3 bytes)
 // 2. Byte-based indexing would fail for non-ASCII hardblanks
@@ -311,8 +351,8 @@ func parseSignature(headerLine string, font *Font) error {
 
 	// Spec says the signature must be exactly "flf2a" (5th char is 'a' and cannot be omitted)
 	signature := string(runes[:5])
-	if signature != "flf2a" {
-		return fmt.Errorf("invalid signature: expected 'flf2a', got %q", signature)
+	if signature != SignatureFLF && signature != SignatureTLF {
+		return fmt.Errorf("invalid signature: expected 'flf2a' or 'tlf2a', got %q", signature)
 	}
 
 	hardblank := runes[5]
@@ -327,7 +367,7 @@ func parseSignature(headerLine string, font *Font) error {
 }
 
 // parseRequiredFields parses the five required header fields
-func parseRequiredFields(fields []string, font *Font) error {
+func parseRequiredFields(fields []string, font *Font, line int) error {
 	// Parse Height
 	height, err := strconv.Atoi(fields[0])
 	if err != nil {
@@ -346,11 +386,14 @@ func parseRequiredFields(fields []string, font *Font) error {
 	// Clamp baseline to valid range rather than rejecting the font.
 	// Many real-world fonts have out-of-spec baseline values that FIGlet
 	// handles gracefully (e.g. baseline=0 or baseline > height).
-	if baseline < 1 {
-		baseline = 1
-	}
-	if baseline > height {
-		baseline = height
+	if baseline < 1 || baseline > height {
+		clamped := min(max(baseline, 1), height)
+		font.warn(Diagnostic{
+			Kind:    KindBaselineOutOfRange,
+			Line:    line,
+			Message: fmt.Sprintf("baseline %d outside 1..%d, using %d", baseline, height, clamped),
+		})
+		baseline = clamped
 	}
 	font.Baseline = baseline
 
@@ -385,7 +428,7 @@ func parseRequiredFields(fields []string, font *Font) error {
 }
 
 // parseOptionalFields parses the optional header fields if present
-func parseOptionalFields(fields []string, font *Font) error {
+func parseOptionalFields(fields []string, font *Font, line int) error {
 	const (
 		printDirectionField = 5
 		fullLayoutField     = 6
@@ -406,12 +449,28 @@ func parseOptionalFields(fields []string, font *Font) error {
 		if val, err := strconv.Atoi(fields[fullLayoutField]); err == nil {
 			font.FullLayout = val
 			font.FullLayoutSet = true
+			switch {
+			case val < 0 || val > maxFullLayout:
+				font.warn(Diagnostic{
+					Kind:    KindInvalidFullLayout,
+					Line:    line,
+					Message: fmt.Sprintf("full layout %d outside 0..%d is ignored", val, maxFullLayout),
+				})
+			case !oldLayoutAgrees(font.OldLayout, val):
+				font.warn(Diagnostic{
+					Kind: KindLayoutMismatch,
+					Line: line,
+					Message: fmt.Sprintf("old layout %d disagrees with full layout %d; full layout is used",
+						font.OldLayout, val),
+				})
+			}
 		}
 	}
 
 	if len(fields) > codetagCountField {
-		if val, err := strconv.Atoi(fields[codetagCountField]); err == nil {
+		if val, err := strconv.Atoi(fields[codetagCountField]); err == nil && val >= 0 {
 			font.CodetagCount = val
+			font.CodetagCountSet = true
 		}
 	}
 
@@ -419,14 +478,16 @@ func parseOptionalFields(fields []string, font *Font) error {
 }
 
 // readCommentLines reads the specified number of comment lines
-func readCommentLines(scanner *bufio.Scanner, font *Font) error {
+func readCommentLines(scanner *lineScanner, font *Font) error {
 	font.Comments = make([]string, 0, font.CommentLines)
 	for i := 0; i < font.CommentLines; i++ {
 		if !scanner.Scan() {
 			if err := scanner.Err(); err != nil {
-				return fmt.Errorf("error reading comment line %d: %w", i+1, err)
+				return newError(KindReadError, scanner.line+1,
+					fmt.Errorf("error reading comment line %d: %w", i+1, err))
 			}
-			return fmt.Errorf("unexpected EOF: expected %d comment lines, got %d", font.CommentLines, i)
+			return newError(KindTruncatedComments, scanner.line,
+				fmt.Errorf("unexpected EOF: expected %d comment lines, got %d", font.CommentLines, i))
 		}
 		// Preserve comment as-is, just trim line endings
 		comment := strings.TrimRight(scanner.Text(), "\r\n")
@@ -435,7 +496,8 @@ func readCommentLines(scanner *bufio.Scanner, font *Font) error {
 	return nil
 }
 
-// parseGlyphs parses the required FIGcharacters: ASCII (32-126) and German (196,214,220,228,246,252,223).
+// parseGlyphs parses the required FIGcharacters: ASCII (32-126) and German (196,214,220,228,246,252,223),
+// followed by any code-tagged FIGcharacters.
 //
 // Parsing Strategy:
 // 1. Always parse ASCII space (32) first - it's required and special
@@ -446,56 +508,246 @@ func readCommentLines(scanner *bufio.Scanner, font *Font) error {
 // The function is permissive about missing characters:
 // - If we hit EOF during ASCII parsing, we accept a partial font
 // - German characters are optional (many fonts omit them)
-// - Missing code-tagged characters are ignored
+// - Fewer code-tagged characters than CodetagCount is accepted with a warning
 //
 // This permissiveness ensures compatibility with the wide variety of
 // FIGfont files in the wild, many of which don't strictly follow the spec.
 // The only hard requirement is the space character (ASCII 32).
-func parseGlyphs(scanner *bufio.Scanner, font *Font) error {
+func parseGlyphs(scanner *lineScanner, font *Font) error {
 	// Parse space character (ASCII 32)
-	spaceGlyph, warnings, err := parseGlyph(scanner, font.Height, font.MaxLength)
+	spaceGlyph, issues, err := parseGlyph(scanner, font.Height, font.MaxLength, font.Hardblank)
 	if err != nil {
-		return fmt.Errorf("error parsing glyph for character 32 (space): %w", err)
+		return wrapGlyphError(err, scanner, ' ', "error parsing glyph for character 32 (space)")
 	}
 	font.Characters[' '] = spaceGlyph
 	// Don't compute trims immediately - do it lazily
-	font.Warnings = append(font.Warnings, warnings...)
+	font.warnGlyph(' ', issues)
 
 	// Parse remaining ASCII characters (33-126)
 	for charCode := rune(firstNonSpaceASCII); charCode <= lastPrintableASCII; charCode++ {
-		glyph, warnings, err := parseGlyph(scanner, font.Height, font.MaxLength)
+		glyph, issues, err := parseGlyph(scanner, font.Height, font.MaxLength, font.Hardblank)
 		if err != nil {
 			// Check if it's EOF - if so, we're done (partial font is OK)
 			if errors.Is(err, io.ErrUnexpectedEOF) {
-				break
+				font.warn(Diagnostic{
+					Kind:    KindTruncatedFont,
+					Line:    scanner.line,
+					Code:    charCode,
+					HasCode: true,
+					Message: fmt.Sprintf("font ends before character %d (%c)", charCode, charCode),
+				})
+				return nil
 			}
-			return fmt.Errorf("error parsing glyph for character %d (%c): %w", charCode, charCode, err)
+			return wrapGlyphError(err, scanner, charCode,
+				fmt.Sprintf("error parsing glyph for character %d (%c)", charCode, charCode))
 		}
 		font.Characters[charCode] = glyph
 		// Don't compute trims immediately - do it lazily
-		font.Warnings = append(font.Warnings, warnings...)
+		font.warnGlyph(charCode, issues)
 	}
 
 	// Parse the 7 required German/Deutsch characters in order
-	// Per FIGfont spec: 196 (Ä), 214 (Ö), 220 (Ü), 228 (ä), 246 (ö), 252 (ü), 223 (ß)
-	deutschChars := []rune{196, 214, 220, 228, 246, 252, 223}
 	for _, charCode := range deutschChars {
-		glyph, warnings, err := parseGlyph(scanner, font.Height, font.MaxLength)
+		glyph, issues, err := parseGlyph(scanner, font.Height, font.MaxLength, font.Hardblank)
 		if err != nil {
 			// Check if it's EOF - German chars are optional for backward compatibility
 			if errors.Is(err, io.ErrUnexpectedEOF) {
-				break
+				font.warn(Diagnostic{
+					Kind:    KindMissingDeutsch,
+					Line:    scanner.line,
+					Code:    charCode,
+					HasCode: true,
+					Message: fmt.Sprintf("font ends before Deutsch character %d", charCode),
+				})
+				return nil
 			}
-			return fmt.Errorf("error parsing glyph for German character %d: %w", charCode, err)
+			return wrapGlyphError(err, scanner, charCode,
+				fmt.Sprintf("error parsing glyph for German character %d", charCode))
 		}
 		font.Characters[charCode] = glyph
 		// Don't compute trims immediately - do it lazily
-		font.Warnings = append(font.Warnings, warnings...)
+		font.warnGlyph(charCode, issues)
+	}
+
+	return parseCodetaggedGlyphs(scanner, font)
+}
+
+// parseCodetaggedGlyphs reads code-tagged FIGcharacters until EOF or, for
+// FIGfonts that declare it, until Codetag_Count characters have been read.
+func parseCodetaggedGlyphs(scanner *lineScanner, font *Font) error {
+	// TOIlet reads code-tagged characters to EOF regardless of Codetag_Count,
+	// and TOIlet fonts commonly leave the field at 0, so only FIGfonts honour it
+	honourCount := font.CodetagCountSet && font.Signature != SignatureTLF
+
+	parsed := 0
+	tagged := make(map[rune]struct{})
+	for !honourCount || parsed < font.CodetagCount {
+		if !scanner.Scan() {
+			if err := scanner.Err(); err != nil {
+				return newError(KindReadError, scanner.line+1, fmt.Errorf("error reading code tag: %w", err))
+			}
+			break
+		}
+
+		line := scanner.Text()
+		code, ok := parseCodeTag(line)
+		if !ok {
+			if strings.TrimSpace(line) != "" {
+				font.warn(Diagnostic{
+					Kind:    KindInvalidCodeTag,
+					Line:    scanner.line,
+					Message: fmt.Sprintf("skipping invalid code tag line %q", line),
+				})
+			}
+			continue
+		}
+		tagLine := scanner.line
+
+		glyph, issues, err := parseGlyph(scanner, font.Height, font.MaxLength, font.Hardblank)
+		if err != nil {
+			if errors.Is(err, io.ErrUnexpectedEOF) {
+				font.warn(Diagnostic{
+					Kind:    KindTruncatedGlyph,
+					Line:    tagLine,
+					Code:    code,
+					HasCode: true,
+					Message: fmt.Sprintf("code-tagged character %d truncated by end of file", code),
+				})
+				break
+			}
+			return wrapGlyphError(err, scanner, code,
+				fmt.Sprintf("error parsing glyph for code-tagged character %d", code))
+		}
+		font.warnGlyph(code, issues)
+		parsed++
+
+		// Code -1 is illegal per spec; other negative codes (typically translation
+		// tables reached via control files) are retained like any other glyph.
+		if code == illegalCharCode {
+			font.warn(Diagnostic{
+				Kind:    KindIllegalCode,
+				Line:    tagLine,
+				Code:    code,
+				HasCode: true,
+				Message: "skipping glyph with illegal character code -1",
+			})
+			continue
+		}
+		// Re-tagging a required character is common (e.g. alternative Deutsch
+		// glyphs), so only a code tagged twice is reported
+		if _, dup := tagged[code]; dup {
+			font.warn(Diagnostic{
+				Kind:    KindDuplicateCode,
+				Line:    tagLine,
+				Code:    code,
+				HasCode: true,
+				Message: fmt.Sprintf("code-tagged character %d is defined more than once", code),
+			})
+		}
+		tagged[code] = struct{}{}
+		font.Characters[code] = glyph
+	}
+
+	if honourCount && parsed < font.CodetagCount {
+		font.warn(Diagnostic{
+			Kind:    KindCodetagCountMismatch,
+			Line:    scanner.line,
+			Message: fmt.Sprintf("expected %d code-tagged characters, got %d", font.CodetagCount, parsed),
+		})
 	}
 
+	if honourCount {
+		return checkTrailingData(scanner, font)
+	}
 	return nil
 }
 
+// checkTrailingData reports the first non-blank line after the characters
+// declared by Codetag_Count, which FIGlet ignores.
+func checkTrailingData(scanner *lineScanner, font *Font) error {
+	for scanner.Scan() {
+		if strings.TrimSpace(scanner.Text()) != "" {
+			font.warn(Diagnostic{
+				Kind:    KindTrailingData,
+				Line:    scanner.line,
+				Message: fmt.Sprintf("ignoring data after the %d declared code-tagged characters", font.CodetagCount),
+			})
+			return nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return newError(KindReadError, scanner.line+1, fmt.Errorf("error reading trailing data: %w", err))
+	}
+	return nil
+}
+
+// oldLayoutAgrees reports whether OldLayout describes the same horizontal
+// layout as FullLayout. OldLayout cannot express universal smushing, so 0 is
+// accepted for it, as FIGlet's own fonts do.
+func oldLayoutAgrees(oldLayout, fullLayout int) bool {
+	const (
+		horzRules    = 63
+		horzFitting  = 64
+		horzSmushing = 128
+	)
+	switch {
+	case fullLayout&horzSmushing != 0 && fullLayout&horzRules == 0:
+		return oldLayout == 0
+	case fullLayout&horzSmushing != 0:
+		return oldLayout == fullLayout&horzRules
+	case fullLayout&horzFitting != 0:
+		return oldLayout == 0
+	default:
+		return oldLayout == -1
+	}
+}
+
+// parseCodeTag extracts the character code from a code tag line.
+//
+// The code is the first whitespace-separated field and may be written in
+// decimal ("161"), octal with a leading zero ("0241") or hexadecimal with a
+// "0x"/"0X" prefix ("0xA1"). A leading "-" makes the code negative. Anything
+// after the code is a comment and is ignored. Codes outside the 32-bit signed
+// range are rejected.
+func parseCodeTag(line string) (rune, bool) {
+	fields := strings.Fields(line)
+	if len(fields) == 0 {
+		return 0, false
+	}
+	digits := fields[0]
+
+	negative := false
+	if strings.HasPrefix(digits, "-") {
+		negative = true
+		digits = digits[1:]
+	}
+
+	base := 10
+	switch {
+	case strings.HasPrefix(digits, "0x") || strings.HasPrefix(digits, "0X"):
+		base = 16
+		digits = digits[2:]
+	case len(digits) > 1 && digits[0] == '0':
+		base = 8
+		digits = digits[1:]
+	}
+
+	// ParseUint rejects signs and empty strings, so "--5" and "0x" fail here
+	val, err := strconv.ParseUint(digits, base, 32)
+	if err != nil {
+		return 0, false
+	}
+
+	code := int64(val)
+	if negative {
+		code = -code
+	}
+	if code < -1<<31 || code > 1<<31-1 {
+		return 0, false
+	}
+	return rune(code), true
+}
+
 // stripTrailingRun strips the trailing run of the last character from a line.
 // Returns the body (without trailing run), the endmark character, and the run length.
 //
@@ -576,35 +828,36 @@ func stripTrailingRun(line string) (body string, endmark rune, runLen int) {
 // The function is permissive about MaxLength violations (only warns) because
 // real-world fonts often exceed their stated MaxLength. Width consistency
 // within a glyph is normalized by padding shorter rows with spaces to match
-// the widest row. Many real-world fonts have inconsistent row widths which
-// the reference FIGlet implementation handles gracefully.
+// the widest row, and reported as an inconsistent-width issue. Many
+// real-world fonts have inconsistent row widths which the reference FIGlet
+// implementation handles gracefully.
+//
+// Issues are returned with their file line numbers; the caller attaches the
+// character code.
 //
 // Note on width calculation: We use rune counts, not visual width. This means
 // combining marks count as separate runes. FIGfonts are primarily ASCII art,
 // so this limitation rarely affects real usage.
 //
-// Memory optimization: Uses pooled slices for glyphs and warnings to reduce
+// Memory optimization: Uses pooled slices for glyphs to reduce
 // allocations in high-throughput scenarios.
-func parseGlyph(scanner *bufio.Scanner, height, maxLength int) (glyph, warnings []string, err error) {
+func parseGlyph(scanner *lineScanner, height, maxLength int, hardblank rune) (
+	glyph []string, issues []Diagnostic, err error,
+) {
 	glyph = acquireGlyphSlice(height)
-	warnings = acquireWarnings()
-	maxWidth := 0 // track widest row for padding
-
-	// Ensure we release the warnings slice if we don't use it
-	needsWarnings := false
-	defer func() {
-		if !needsWarnings && len(warnings) == 0 {
-			releaseWarnings(warnings)
-			warnings = nil
-		}
-	}()
+	startLine := scanner.line + 1
+	maxWidth := 0   // track widest row for padding
+	firstWidth := 0 // width of the first row, to detect ragged glyphs
+	ragged := false
+	var firstEndmark rune
+	endmarkReported := false
 
 	for row := 0; row < height; row++ {
 		if !scanner.Scan() {
 			if err := scanner.Err(); err != nil {
-				return nil, warnings, fmt.Errorf("error reading line %d: %w", row+1, err)
+				return nil, issues, fmt.Errorf("error reading line %d: %w", row+1, err)
 			}
-			return nil, warnings, fmt.Errorf(
+			return nil, issues, fmt.Errorf(
 				"unexpected EOF: expected %d lines, got %d: %w",
 				height, row, io.ErrUnexpectedEOF)
 		}
@@ -613,7 +866,29 @@ func parseGlyph(scanner *bufio.Scanner, height, maxLength int) (glyph, warnings
 		rawLine := scanner.Text()
 
 		// Strip the trailing run of the endmark character
-		body, _, _ := stripTrailingRun(rawLine)
+		body, endmark, _ := stripTrailingRun(rawLine)
+
+		// Endmarks are advisory too: report rows that disagree with the first
+		// row's endmark, and an endmark equal to the hardblank, which makes
+		// trailing hardblanks indistinguishable from endmarks
+		switch {
+		case row == 0:
+			firstEndmark = endmark
+			if endmark == hardblank {
+				issues = append(issues, Diagnostic{
+					Kind:    KindHardblankEndmark,
+					Line:    scanner.line,
+					Message: fmt.Sprintf("endmark %q is the hardblank; trailing hardblanks are stripped", endmark),
+				})
+			}
+		case endmark != firstEndmark && !endmarkReported:
+			endmarkReported = true
+			issues = append(issues, Diagnostic{
+				Kind:    KindInconsistentEndmark,
+				Line:    scanner.line,
+				Message: fmt.Sprintf("row %d ends with %q, expected endmark %q", row+1, endmark, firstEndmark),
+			})
+		}
 
 		// Validate MaxLength after stripping endmarks (advisory check)
 		// The spec says MaxLength is "usually width + 2" for endmarks,
@@ -621,12 +896,19 @@ func parseGlyph(scanner *bufio.Scanner, height, maxLength int) (glyph, warnings
 		bodyWidth := utf8.RuneCountInString(body)
 		if bodyWidth > maxLength {
 			// Advisory warning only - many real fonts exceed their stated MaxLength
-			needsWarnings = true
-			warnings = append(warnings,
-				fmt.Sprintf("line %d width (%d) exceeds MaxLength (%d)",
-					row+1, bodyWidth, maxLength))
+			issues = append(issues, Diagnostic{
+				Kind: KindMaxLengthExceeded,
+				Line: scanner.line,
+				Message: fmt.Sprintf("line %d width (%d) exceeds MaxLength (%d)",
+					row+1, bodyWidth, maxLength),
+			})
 		}
 
+		if row == 0 {
+			firstWidth = bodyWidth
+		} else if bodyWidth != firstWidth {
+			ragged = true
+		}
 		if bodyWidth > maxWidth {
 			maxWidth = bodyWidth
 		}
@@ -634,16 +916,50 @@ func parseGlyph(scanner *bufio.Scanner, height, maxLength int) (glyph, warnings
 		glyph = append(glyph, body)
 	}
 
-	// Pad shorter rows with spaces to match the widest row.
-	// Many real-world fonts have inconsistent row widths (e.g. blank rows
-	// that are empty strings while other rows have content). The reference
-	// FIGlet implementation handles this gracefully.
+	if ragged {
+		issues = append(issues, Diagnostic{
+			Kind:    KindInconsistentWidth,
+			Line:    startLine,
+			Message: fmt.Sprintf("rows differ in width, padded to %d", maxWidth),
+		})
+	}
+	padRows(glyph, maxWidth)
+
+	return glyph, issues, nil
+}
+
+// padRows pads shorter rows with spaces to match width, in place.
+// Many real-world fonts have inconsistent row widths (e.g. blank rows
+// that are empty strings while other rows have content). The reference
+// FIGlet implementation handles this gracefully.
+func padRows(glyph []string, width int) {
 	for i, row := range glyph {
 		w := utf8.RuneCountInString(row)
-		if w < maxWidth {
-			glyph[i] = row + strings.Repeat(" ", maxWidth-w)
+		if w < width {
+			glyph[i] = row + strings.Repeat(" ", width-w)
+		}
+	}
+}
+
+// NormalizeGlyph validates glyph rows supplied in code and returns a copy
+// normalized the way parseGlyph normalizes rows read from a file: there must
+// be exactly height rows, none may contain a line break, and shorter rows are
+// padded with spaces to the width (in runes) of the widest row.
+func NormalizeGlyph(rows []string, height int) ([]string, error) {
+	if len(rows) != height {
+		return nil, fmt.Errorf("expected %d rows, got %d", height, len(rows))
+	}
+
+	glyph := make([]string, len(rows))
+	maxWidth := 0
+	for i, row := range rows {
+		if strings.ContainsAny(row, "\r\n") {
+			return nil, fmt.Errorf("row %d contains a line break", i+1)
 		}
+		maxWidth = max(maxWidth, utf8.RuneCountInString(row))
+		glyph[i] = row
 	}
+	padRows(glyph, maxWidth)
 
-	return glyph, warnings, nil
+	return glyph, nil
 }
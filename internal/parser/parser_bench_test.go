@@ -88,8 +88,8 @@ func BenchmarkParseGlyph(b *testing.B) {
 			b.ResetTimer()
 			for b.Loop() {
 				scanner := strings.NewReader(content)
-				s := bufio.NewScanner(scanner)
-				_, _, _ = parseGlyph(s, tt.height, 100)
+				s := newLineScanner(bufio.NewScanner(scanner))
+				_, _, _ = parseGlyph(s, tt.height, 100, '$')
 			}
 		})
 	}
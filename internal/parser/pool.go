@@ -37,14 +37,6 @@ var glyphSlicePool = sync.Pool{
 	},
 }
 
-// warningsPool for warning messages
-var warningsPool = sync.Pool{
-	New: func() interface{} {
-		s := make([]string, 0, 5)
-		return &s
-	},
-}
-
 // acquireScannerBuffer gets a buffer for the scanner from the pool
 func acquireScannerBuffer() []byte {
 	bufPtrInterface := scannerPool.Get()
@@ -79,15 +71,15 @@ func releaseScannerBuffer(buf []byte) {
 	}
 }
 
-// createPooledScanner creates a scanner with a pooled buffer
-func createPooledScanner(r io.Reader) (*bufio.Scanner, []byte) {
+// createPooledScanner creates a line-counting scanner with a pooled buffer
+func createPooledScanner(r io.Reader) (*lineScanner, []byte) {
 	scanner := bufio.NewScanner(r)
 	buf := acquireScannerBuffer()
 
 	// Set the buffer for the scanner
 	scanner.Buffer(buf, maxScannerBufferSize)
 
-	return scanner, buf
+	return newLineScanner(scanner), buf
 }
 
 // acquireGlyphSlice gets a string slice for glyph data from the pool.
@@ -134,31 +126,3 @@ func releaseGlyphSlice(slice []string) {
 	slice = slice[:0]
 	glyphSlicePool.Put(&slice)
 }
-
-// acquireWarnings gets a warnings slice from the pool
-func acquireWarnings() []string {
-	slicePtrInterface := warningsPool.Get()
-	slicePtr, ok := slicePtrInterface.(*[]string)
-	if !ok {
-		// Fallback: allocate new slice
-		return make([]string, 0, 5)
-	}
-	slice := *slicePtr
-	return slice[:0]
-}
-
-// releaseWarnings returns a warnings slice to the pool
-func releaseWarnings(slice []string) {
-	if slice == nil {
-		return
-	}
-
-	// Clear references
-	for i := range slice {
-		slice[i] = ""
-	}
-
-	// Reset and return to pool
-	slice = slice[:0]
-	warningsPool.Put(&slice)
-}
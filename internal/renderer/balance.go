@@ -0,0 +1,161 @@
+package renderer
+
+import (
+	"github.com/ryanlewis/figgo/internal/parser"
+)
+
+// balancedWord is a run of non-space input runes, with the index of the
+// first rune of the spaces before it.
+type balancedWord struct {
+	gap, start, end int
+}
+
+// balancedBreak is a chosen line break: the spaces runes[gap:start] between
+// two words are dropped and the line wraps there.
+type balancedBreak struct {
+	gap, start int
+}
+
+// balanceLines chooses where each line of input breaks so that the output
+// lines come out as even as possible, and returns the breaks in input order.
+//
+// Candidate lines are measured by rendering them with the real kerning and
+// smushing distances. Breakpoints are chosen by dynamic programming over the
+// words of each input line, minimising first the number of output lines and
+// then the sum of the squared free space on every line, the last included
+// (Knuth–Plass style minimum raggedness). Ties go to the shorter last line,
+// so the result is deterministic. A word wider than a line gets a line of its
+// own and is left to the usual wrapping.
+func (state *renderState) balanceLines(runes []rune, font *parser.Font, opts *Options) ([]balancedBreak, error) {
+	measure := acquireRenderState(state.charHeight, state.hardblank, 0)
+	defer releaseRenderState(measure)
+	measure.debug = nil // pooled states keep their last session
+	measure.outlineLenLimit = state.outlineLenLimit
+	measure.right2left = state.right2left
+	measure.smushMode = state.smushMode
+
+	var breaks []balancedBreak
+	start := 0
+	for i := 0; i <= len(runes); i++ {
+		if i < len(runes) && runes[i] != '\n' {
+			continue
+		}
+		var err error
+		breaks, err = measure.balanceLine(breaks, runes[start:i], start, font, opts)
+		if err != nil {
+			return nil, err
+		}
+		start = i + 1
+	}
+	return breaks, nil
+}
+
+// balanceLine breaks one line of input (without newlines), which starts at
+// index offset of the input, into balanced output lines and appends its
+// breaks to breaks. It uses state as scratch space for measuring.
+func (state *renderState) balanceLine(breaks []balancedBreak, line []rune, offset int, font *parser.Font, opts *Options) ([]balancedBreak, error) {
+	words := splitBalancedWords(line)
+	if len(words) < 2 {
+		return breaks, nil
+	}
+
+	// widths[i][j] is the width of words i..i+j on one line; each row stops
+	// at the first line that does not fit
+	widths := make([][]int, len(words))
+	for i := range words {
+		state.clearOutputLine()
+		from := words[i].start
+		if i == 0 {
+			from = 0 // keep the line's leading spaces
+		}
+		for j := i; j < len(words); j++ {
+			fits, err := state.measureRunes(line[from:words[j].end], font, opts)
+			if err != nil {
+				return nil, err
+			}
+			if !fits {
+				break
+			}
+			widths[i] = append(widths[i], state.outlineLen)
+			from = words[j].end
+		}
+	}
+
+	// lines[k] and cost[k] are the best break of the first k words
+	n := len(words)
+	lines := make([]int, n+1)
+	cost := make([]int, n+1)
+	from := make([]int, n+1)
+	for k := 1; k <= n; k++ {
+		lines[k] = -1
+		for i := k - 1; i >= 0; i-- {
+			slack := 0 // a word wider than a line is on its own
+			if j := k - 1 - i; j < len(widths[i]) {
+				slack = state.outlineLenLimit - widths[i][j]
+			} else if i < k-1 {
+				break // starting earlier only makes the line wider
+			}
+			l, c := lines[i]+1, cost[i]+slack*slack
+			if lines[k] < 0 || l < lines[k] || l == lines[k] && c < cost[k] {
+				lines[k], cost[k], from[k] = l, c, i
+			}
+		}
+	}
+
+	// Walk back from the last word to the first line start, then record the
+	// breaks in order
+	var starts []int
+	for k := n; from[k] > 0; k = from[k] {
+		starts = append(starts, from[k])
+	}
+	for b := len(starts) - 1; b >= 0; b-- {
+		word := words[starts[b]]
+		breaks = append(breaks, balancedBreak{gap: offset + word.gap, start: offset + word.start})
+	}
+	return breaks, nil
+}
+
+// measureRunes adds runes to the current line the way processInputRune
+// would, and reports whether they all fit.
+func (state *renderState) measureRunes(runes []rune, font *parser.Font, opts *Options) (bool, error) {
+	for _, r := range runes {
+		if r == '\t' {
+			r = ' '
+		}
+		if r >= 0 && r < ' ' {
+			continue
+		}
+		glyph, r, err := state.lookupGlyph(r, font, opts)
+		if err != nil {
+			return false, err
+		}
+		state.processingSpaceGlyph = r == ' '
+		fits := state.addChar(glyph)
+		state.processingSpaceGlyph = false
+		if !fits {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// splitBalancedWords returns the words of line, which are separated by
+// spaces and tabs.
+func splitBalancedWords(line []rune) []balancedWord {
+	var words []balancedWord
+	gap := 0
+	for i := 0; i < len(line); {
+		if line[i] == ' ' || line[i] == '\t' {
+			i++
+			continue
+		}
+		word := balancedWord{gap: gap, start: i}
+		for i < len(line) && line[i] != ' ' && line[i] != '\t' {
+			i++
+		}
+		word.end = i
+		words = append(words, word)
+		gap = i
+	}
+	return words
+}
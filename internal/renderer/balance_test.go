@@ -0,0 +1,80 @@
+package renderer
+
+import (
+	"testing"
+)
+
+func TestRenderBalancedWrap(t *testing.T) {
+	font := textFont()
+
+	tests := []struct {
+		name  string
+		text  string
+		width int
+		opts  Options
+		want  string
+	}{
+		{
+			name:  "greedy leaves a short last line",
+			text:  "Release notes for v2",
+			width: 16,
+			want:  "Release notes\nfor v2",
+		},
+		{
+			name:  "balanced",
+			text:  "Release notes for v2",
+			width: 16,
+			opts:  Options{BalancedWrap: true},
+			want:  "Release\nnotes for v2",
+		},
+		{
+			name:  "ties go to the shorter last line",
+			text:  "aa bb cc dd ee",
+			width: 9,
+			opts:  Options{BalancedWrap: true},
+			want:  "aa bb cc\ndd ee",
+		},
+		{
+			name:  "each input line is balanced on its own",
+			text:  "Release notes for v2\nok",
+			width: 16,
+			opts:  Options{BalancedWrap: true},
+			want:  "Release\nnotes for v2\nok",
+		},
+		{
+			name:  "word wider than a line gets its own lines",
+			text:  "a bbbbbbbbbbb c",
+			width: 6,
+			opts:  Options{BalancedWrap: true},
+			want:  "a\nbbbbb\nbbbbb\nb\nc",
+		},
+		{
+			name:  "fits on one line",
+			text:  "short text",
+			width: 80,
+			opts:  Options{BalancedWrap: true},
+			want:  "short text",
+		},
+		{
+			name:  "paragraph mode joins lines first",
+			text:  "Release notes\nfor v2",
+			width: 16,
+			opts:  Options{BalancedWrap: true, ParagraphMode: true},
+			want:  "Release\nnotes for v2",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := tt.opts
+			opts.Width = &tt.width
+			got, err := Render(tt.text, font, &opts)
+			if err != nil {
+				t.Fatalf("Render() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Render() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
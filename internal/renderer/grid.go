@@ -0,0 +1,213 @@
+package renderer
+
+import (
+	"github.com/ryanlewis/figgo/internal/debug"
+	"github.com/ryanlewis/figgo/internal/parser"
+)
+
+// Cell is one column of one output row, and where it came from.
+type Cell struct {
+	// Rune is the character as written; hardblanks are spaces
+	Rune rune
+	// Source is the index of the input rune that produced the cell, or -1
+	// when no input rune did (justification padding and inserted hyphens)
+	Source int
+	// Smushed is true when two visible characters were merged into the cell
+	Smushed bool
+	// SmushRule names the rule that merged them, as debug.ClassifySmushRule
+	// does, or "vertical" when output lines were smushed together
+	SmushRule string
+	// Hardblank is true when the cell holds one of the font's hardblanks
+	Hardblank bool
+}
+
+// blankCell fills columns that no glyph has written to.
+var blankCell = Cell{Rune: ' ', Source: -1}
+
+// gridState tracks where each character of the output came from while
+// rendering a grid. Its cells hold raw runes, hardblanks included, and run
+// parallel to the rune buffers of renderState.
+type gridState struct {
+	line    [][]Cell // Cells of the current output line, one slice per row
+	temp    []Cell   // Scratch row for right-to-left merging
+	block   [][]Cell // Cells of the line being stacked by appendBlock
+	sources []int    // Input index of each rune in inputBuffer
+	rows    [][]Cell // Completed rows
+}
+
+// RenderGrid lays out text exactly as Render would, but returns the output
+// as rows of cells recording where each character came from instead of
+// encoding it. Source indexes count runes of text, or character codes of the
+// input filter's output when one is set.
+func RenderGrid(text string, font *parser.Font, opts *Options) ([][]Cell, error) {
+	if font == nil {
+		return nil, ErrNilFont
+	}
+
+	state := acquireRenderState(font.Height, font.Hardblank, len(text))
+	defer releaseRenderState(state)
+
+	g := &gridState{line: make([][]Cell, font.Height)}
+	state.initFromOptions(font, opts)
+	state.grid = g
+	defer func() { state.grid = nil }()
+
+	if err := state.processText(text, font, opts); err != nil {
+		return nil, err
+	}
+
+	if state.vertMode != VertFull {
+		// Stacked rows are padded already; finish them as writeRows would
+		for i, row := range g.rows {
+			g.rows[i] = state.finishCells(row, 0)
+		}
+	}
+	if len(g.rows) == 0 {
+		// Like Render, empty output is one blank line of the font's height
+		g.rows = make([][]Cell, font.Height)
+	}
+	return g.rows, nil
+}
+
+// finishCells turns a row of raw cells into output cells as appendRow does
+// for runes: trailing spaces are trimmed when asked, pad cells of
+// justification go before rows with content, and hardblanks become spaces.
+func (state *renderState) finishCells(cells []Cell, pad int) []Cell {
+	n := len(cells)
+	if state.trimWhitespace {
+		for n > 0 && cells[n-1].Rune == ' ' {
+			n--
+		}
+	}
+	if n == 0 {
+		return nil
+	}
+
+	row := make([]Cell, 0, pad+n)
+	for ; pad > 0; pad-- {
+		row = append(row, blankCell)
+	}
+	for _, c := range cells[:n] {
+		if c.Rune == state.hardblank {
+			c.Rune = ' '
+			c.Hardblank = true
+		}
+		row = append(row, c)
+	}
+	return row
+}
+
+// flushGrid adds the cells of the line being flushed to the grid. Stacked
+// lines are padded and kept raw for appendBlock to overlap.
+func (state *renderState) flushGrid(pad int) {
+	g := state.grid
+	if state.vertMode == VertFull {
+		for i := 0; i < state.charHeight; i++ {
+			g.rows = append(g.rows, state.finishCells(g.line[i], pad))
+		}
+		return
+	}
+
+	g.block = make([][]Cell, state.charHeight)
+	for i := range g.block {
+		row := make([]Cell, pad, pad+len(g.line[i]))
+		for j := range row {
+			row[j] = blankCell
+		}
+		g.block[i] = append(row, g.line[i]...)
+	}
+}
+
+// clearLine empties the cells of the current output line.
+func (g *gridState) clearLine() {
+	for i := range g.line {
+		g.line[i] = g.line[i][:0]
+	}
+}
+
+// recordSource records the input index of the rune stored at inputBuffer[i].
+func (g *gridState) recordSource(i, source int) {
+	if len(g.sources) <= i {
+		g.sources = append(g.sources, source)
+	} else {
+		g.sources[i] = source
+	}
+}
+
+// appendBlock mirrors renderState.appendBlock for the cells of g.block,
+// given the rune rows that overlapped and what they merged into.
+func (g *gridState) appendBlock(top int, upper, lower, merged [][]rune) {
+	for i := range merged {
+		g.rows[top+i] = mergeVerticalCells(g.rows[top+i], g.block[i], upper[i], lower[i], merged[i])
+	}
+	g.rows = append(g.rows, g.block[len(merged):]...)
+	g.block = nil
+}
+
+// mergeVerticalCells returns the cells of a row smushed from the rows above
+// and below it; each column keeps the cell of the character that won.
+func mergeVerticalCells(topCells, bottomCells []Cell, top, bottom, merged []rune) []Cell {
+	cells := make([]Cell, len(merged))
+	for i, r := range merged {
+		tc, bc := blankCell, blankCell
+		if i < len(top) {
+			tc = topCells[i]
+		}
+		if i < len(bottom) {
+			bc = bottomCells[i]
+		}
+
+		switch {
+		case bc.Rune == ' ':
+			cells[i] = tc
+		case tc.Rune == ' ':
+			cells[i] = bc
+		case r == tc.Rune && r != bc.Rune:
+			cells[i] = tc
+			cells[i].Smushed, cells[i].SmushRule = true, "vertical"
+		default:
+			cells[i] = bc
+			cells[i].Smushed, cells[i].SmushRule = true, "vertical"
+		}
+		cells[i].Rune = r
+	}
+	return cells
+}
+
+// smushedCell returns the cell for a column where the left and right cells
+// overlapped and smushed to result. A blank side leaves the other's cell;
+// otherwise the cell belongs to the character being added.
+func (state *renderState) smushedCell(left, right Cell, result rune) Cell {
+	switch {
+	case right.Rune == ' ' || right.Rune == 0:
+		left.Rune = result
+		return left
+	case left.Rune == ' ' || left.Rune == 0:
+		right.Rune = result
+		return right
+	}
+
+	rule := debug.ClassifySmushRule(left.Rune, right.Rune, result, state.smushMode)
+	if left.Rune == state.hardblank && right.Rune == state.hardblank {
+		rule = "hardblank" // ClassifySmushRule does not know the hardblank
+	}
+	return Cell{Rune: result, Source: state.source, Smushed: true, SmushRule: rule}
+}
+
+// setCell sets cells[i], growing cells with blank cells as needed.
+func setCell(cells []Cell, i int, c Cell) []Cell {
+	for len(cells) <= i {
+		cells = append(cells, blankCell)
+	}
+	cells[i] = c
+	return cells
+}
+
+// fitCells returns cells with exactly n cells, growing with blank cells as
+// needed.
+func fitCells(cells []Cell, n int) []Cell {
+	for len(cells) < n {
+		cells = append(cells, blankCell)
+	}
+	return cells[:n]
+}
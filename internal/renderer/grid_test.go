@@ -0,0 +1,121 @@
+package renderer
+
+import (
+	"reflect"
+	"testing"
+)
+
+// gridSources returns the source of every cell of each row.
+func gridSources(rows [][]Cell) [][]int {
+	out := make([][]int, len(rows))
+	for i, row := range rows {
+		out[i] = []int{}
+		for _, c := range row {
+			out[i] = append(out[i], c.Source)
+		}
+	}
+	return out
+}
+
+func TestRenderGrid(t *testing.T) {
+	font := textFont()
+	width := 6 // lines hold 5 characters
+	rtl := 1
+
+	tests := []struct {
+		name string
+		text string
+		opts Options
+		want [][]int
+	}{
+		{name: "one line", text: "abc", want: [][]int{{0, 1, 2}}},
+		{name: "newline", text: "ab\ncd", want: [][]int{{0, 1}, {3, 4}}},
+		{name: "split at a space", text: "ab cdef", opts: Options{Width: &width}, want: [][]int{{0, 1}, {3, 4, 5, 6}}},
+		{name: "word broken", text: "abcdefg", opts: Options{Width: &width}, want: [][]int{{0, 1, 2, 3, 4}, {5, 6}}},
+		{
+			name: "hyphen has no source",
+			text: "abcdefg",
+			opts: Options{Width: &width, WrapMode: WrapChar, Hyphenate: true},
+			want: [][]int{{0, 1, 2, 3, -1}, {4, 5, 6}},
+		},
+		{name: "right to left", text: "abc", opts: Options{PrintDirection: &rtl}, want: [][]int{{2, 1, 0}}},
+		{
+			name: "right to left split",
+			text: "ab cdef",
+			opts: Options{Width: &width, PrintDirection: &rtl},
+			want: [][]int{{1, 0}, {6, 5, 4, 3}},
+		},
+		{
+			name: "justified",
+			text: "ab",
+			opts: Options{Width: &width, Justify: JustifyRight},
+			want: [][]int{{-1, -1, -1, 0, 1}},
+		},
+		{name: "balanced", text: "aa bb cc", opts: Options{Width: &width, BalancedWrap: true}, want: [][]int{{0, 1, 2, 3, 4}, {6, 7}}},
+		{name: "empty", text: "", want: [][]int{{}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rows, err := RenderGrid(tt.text, font, &tt.opts)
+			if err != nil {
+				t.Fatalf("RenderGrid() error = %v", err)
+			}
+			if got := gridSources(rows); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("sources = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSmushedCell(t *testing.T) {
+	state := &renderState{hardblank: '$', smushMode: SMSmush | SMEqual | SMHardblank, source: 5}
+	a := Cell{Rune: 'a', Source: 1}
+
+	tests := []struct {
+		name        string
+		left, right Cell
+		result      rune
+		want        Cell
+	}{
+		{name: "blank right keeps left", left: a, right: Cell{Rune: ' ', Source: 2}, result: 'a', want: a},
+		{name: "blank left keeps right", left: blankCell, right: a, result: 'a', want: a},
+		{
+			name:   "equal",
+			left:   Cell{Rune: '|', Source: 1},
+			right:  Cell{Rune: '|', Source: 5},
+			result: '|',
+			want:   Cell{Rune: '|', Source: 5, Smushed: true, SmushRule: "equal"},
+		},
+		{
+			name:   "hardblank",
+			left:   Cell{Rune: '$', Source: 1},
+			right:  Cell{Rune: '$', Source: 5},
+			result: '$',
+			want:   Cell{Rune: '$', Source: 5, Smushed: true, SmushRule: "hardblank"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := state.smushedCell(tt.left, tt.right, tt.result); got != tt.want {
+				t.Errorf("smushedCell() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMergeVerticalCells(t *testing.T) {
+	top := []Cell{{Rune: 'A', Source: 0}, {Rune: ' ', Source: 0}, {Rune: '-', Source: 0}}
+	bottom := []Cell{{Rune: ' ', Source: 2}, {Rune: 'B', Source: 2}, {Rune: '_', Source: 2}}
+
+	got := mergeVerticalCells(top, bottom, []rune("A -"), []rune(" B_"), []rune("AB="))
+	want := []Cell{
+		{Rune: 'A', Source: 0},
+		{Rune: 'B', Source: 2},
+		{Rune: '=', Source: 2, Smushed: true, SmushRule: "vertical"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("mergeVerticalCells() = %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,85 @@
+package renderer
+
+import (
+	"testing"
+
+	"github.com/ryanlewis/figgo/internal/parser"
+)
+
+func TestRenderJustify(t *testing.T) {
+	glyphs := map[rune][]string{' ': {"$", "$"}}
+	for r := rune(33); r <= 126; r++ {
+		glyphs[r] = []string{string(r) + string(r), "||"}
+	}
+	font := &parser.Font{Height: 2, Hardblank: '$', Characters: glyphs, OldLayout: -1}
+	width := 10
+	rtl := 1
+
+	tests := []struct {
+		name string
+		text string
+		opts Options
+		want string
+	}{
+		{
+			name: "left",
+			text: "ab",
+			opts: Options{Width: &width, Justify: JustifyLeft},
+			want: "aabb\n||||",
+		},
+		{
+			name: "center",
+			text: "ab",
+			opts: Options{Width: &width, Justify: JustifyCenter},
+			want: "   aabb\n   ||||",
+		},
+		{
+			name: "right ends at width minus one",
+			text: "ab",
+			opts: Options{Width: &width, Justify: JustifyRight},
+			want: "     aabb\n     ||||",
+		},
+		{
+			name: "each line padded separately",
+			text: "ab\nc",
+			opts: Options{Width: &width, Justify: JustifyRight},
+			want: "     aabb\n     ||||\n       cc\n       ||",
+		},
+		{
+			name: "auto is left for left-to-right",
+			text: "ab",
+			opts: Options{Width: &width, Justify: JustifyAuto},
+			want: "aabb\n||||",
+		},
+		{
+			name: "auto is right for right-to-left",
+			text: "ab",
+			opts: Options{Width: &width, Justify: JustifyAuto, PrintDirection: &rtl},
+			want: "     bbaa\n     ||||",
+		},
+		{
+			name: "default width is 80",
+			text: "ab",
+			opts: Options{Justify: JustifyCenter},
+			want: "                                      aabb\n                                      ||||",
+		},
+		{
+			name: "vertical fitting keeps padding",
+			text: "ab\nc",
+			opts: Options{Width: &width, Justify: JustifyCenter, VerticalMode: VertSmushUniversal},
+			want: "   aabb\n   |cc|\n    ||",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Render(tt.text, font, &tt.opts)
+			if err != nil {
+				t.Fatalf("Render() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Render() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package renderer
+
+import (
+	"github.com/ryanlewis/figgo/internal/parser"
+)
+
+// Metrics describes rendered output without the output itself.
+type Metrics struct {
+	// LineWidths is the width in columns of each output line, as written
+	LineWidths []int
+	// Height is the number of output rows
+	Height int
+	// WrappedLines counts output lines that end at a break made to fit the width
+	WrappedLines int
+	// Glyphs counts the glyphs placed in the output
+	Glyphs int
+}
+
+// Measure lays out text exactly as Render would, through the same fitting,
+// smushing and line breaking, but records metrics instead of encoding rows.
+// Output rows are only kept when lines are stacked with vertical fitting or
+// smushing, which needs them to find the overlap.
+func Measure(text string, font *parser.Font, opts *Options) (Metrics, error) {
+	if font == nil {
+		return Metrics{}, ErrNilFont
+	}
+
+	state := acquireRenderState(font.Height, font.Hardblank, len(text))
+	defer releaseRenderState(state)
+
+	var m Metrics
+	state.initFromOptions(font, opts)
+	state.metrics = &m
+	defer func() { state.metrics = nil }()
+
+	if err := state.processText(text, font, opts); err != nil {
+		return Metrics{}, err
+	}
+
+	if state.vertMode != VertFull {
+		m.Height = len(state.outputRows)
+	} else {
+		m.Height = len(m.LineWidths) * font.Height
+	}
+	return m, nil
+}
+
+// recordLine adds the line being flushed to the metrics. The width matches
+// appendRow: trailing spaces count unless trimmed, and the justification pad
+// is only written before rows with content.
+func (state *renderState) recordLine(pad int) {
+	width := 0
+	for i := 0; i < state.charHeight; i++ {
+		n := state.rowLengths[i]
+		if state.trimWhitespace {
+			for n > 0 && state.outputLine[i][n-1] == ' ' {
+				n--
+			}
+		}
+		if n > 0 {
+			width = max(width, pad+n)
+		}
+	}
+
+	state.metrics.LineWidths = append(state.metrics.LineWidths, width)
+	state.metrics.Glyphs += state.lineGlyphs
+	if !state.hardBreak {
+		state.metrics.WrappedLines++
+	}
+}
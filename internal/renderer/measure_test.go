@@ -0,0 +1,69 @@
+package renderer
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMeasure(t *testing.T) {
+	font := textFont()
+	width := 6 // lines hold 5 characters
+
+	tests := []struct {
+		name string
+		text string
+		opts Options
+		want Metrics
+	}{
+		{
+			name: "single line",
+			text: "abc",
+			want: Metrics{LineWidths: []int{3}, Height: 1, Glyphs: 3},
+		},
+		{
+			name: "newlines are not wrapped lines",
+			text: "ab\ncde",
+			want: Metrics{LineWidths: []int{2, 3}, Height: 2, Glyphs: 5},
+		},
+		{
+			name: "wrapped at a space",
+			text: "ab cd ef",
+			opts: Options{Width: &width},
+			want: Metrics{LineWidths: []int{5, 2}, Height: 2, WrappedLines: 1, Glyphs: 7},
+		},
+		{
+			name: "trailing spaces trimmed",
+			text: "ab  ",
+			opts: Options{TrimWhitespace: true},
+			want: Metrics{LineWidths: []int{2}, Height: 1, Glyphs: 4},
+		},
+		{
+			name: "justification pad counted",
+			text: "ab",
+			opts: Options{Width: &width, Justify: JustifyRight},
+			want: Metrics{LineWidths: []int{5}, Height: 1, Glyphs: 2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Measure(tt.text, font, &tt.opts)
+			if err != nil {
+				t.Fatalf("Measure() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Measure() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReleaseRenderStateKeepsOutputLines(t *testing.T) {
+	state := acquireRenderState(2, '$', 0)
+	line := &state.outputLine[0][0]
+	releaseRenderState(state)
+
+	if state.outputLine[0] == nil || &state.outputLine[0][0] != line {
+		t.Error("releaseRenderState() dropped a default-sized output line")
+	}
+}
@@ -0,0 +1,56 @@
+package renderer
+
+import (
+	"testing"
+
+	"github.com/ryanlewis/figgo/internal/parser"
+)
+
+func TestRenderParagraphMode(t *testing.T) {
+	glyphs := map[rune][]string{' ': {"$", "$"}}
+	for r := rune(33); r <= 126; r++ {
+		glyphs[r] = []string{string(r) + string(r), "||"}
+	}
+	font := &parser.Font{Height: 2, Hardblank: '$', Characters: glyphs, OldLayout: -1}
+	width := 12
+
+	// Each input renders in paragraph mode like same renders normally
+	tests := []struct {
+		name string
+		text string
+		same string
+	}{
+		{name: "single newline is a space", text: "ab\ncd", same: "ab cd"},
+		{name: "blank line breaks", text: "ab\n\ncd", same: "ab\ncd"},
+		{name: "indented line breaks", text: "ab\n cd", same: "ab\n cd"},
+		{name: "crlf", text: "ab\r\ncd\r\n\r\nef", same: "ab cd\nef"},
+		{name: "trailing newline", text: "ab\n", same: "ab "},
+		{name: "reflowed paragraph wraps", text: "ab cd\nef gh", same: "ab cd ef gh"},
+	}
+
+	filter := func(text string) []rune { return []rune(text) }
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want, err := Render(tt.same, font, &Options{Width: &width})
+			if err != nil {
+				t.Fatalf("Render() error = %v", err)
+			}
+			got, err := Render(tt.text, font, &Options{Width: &width, ParagraphMode: true})
+			if err != nil {
+				t.Fatalf("Render() error = %v", err)
+			}
+			if got != want {
+				t.Errorf("Render() = %q, want %q", got, want)
+			}
+
+			// The input filter path reflows the same way
+			got, err = Render(tt.text, font, &Options{Width: &width, ParagraphMode: true, InputFilter: filter})
+			if err != nil {
+				t.Fatalf("Render() with filter error = %v", err)
+			}
+			if got != want {
+				t.Errorf("Render() with filter = %q, want %q", got, want)
+			}
+		})
+	}
+}
@@ -12,9 +12,9 @@ const (
 
 	// Buffer retention thresholds - buffers larger than these are released
 	// to prevent memory bloat in the pool from occasional large renders
-	maxRetainInputBuffer  = 1024 // 4KB for rune slice
-	maxRetainOutputBuffer = 8192 // 8KB for byte slice
-	maxRetainOutputLine   = 2000 // ~8KB per line for rune slice
+	maxRetainInputBuffer  = 1024                // 4KB for rune slice
+	maxRetainOutputBuffer = 8192                // 8KB for byte slice
+	maxRetainOutputLine   = defaultOutlineLimit // output lines are always this size; keep them
 )
 
 // renderStatePool manages a pool of renderState objects to reduce allocations.
@@ -97,15 +97,31 @@ func acquireRenderState(height int, hardblank rune, textLen int) *renderState {
 	state.previousCharWidth = 0
 	state.right2left = 0
 	state.smushMode = 0
+	state.vertMode = VertFull
+	state.vertRules = 0
+	state.justify = JustifyLeft
+	state.justifyWidth = 0
 	state.trimWhitespace = false
+	state.paragraphMode = false
+	state.wrapMode = WrapWordThenChar
+	state.hyphenate = false
+	state.balancedWrap = false
+	state.hardBreak = false
+	state.lineGlyphs = 0
+	state.metrics = nil
+	state.grid = nil
+	state.source = -1
+	state.overflowLine = false
+	state.lastWasEOL = false
 
 	// Reset new line breaking fields
 	state.inputCount = 0
 	state.lastWordBreak = -1
 	state.wordbreakmode = 0
 
-	// Reset output buffer
+	// Reset output buffers
 	state.outputBuffer = state.outputBuffer[:0]
+	state.outputRows = state.outputRows[:0]
 
 	// Resize slices if needed (without reallocating if possible)
 	if cap(state.outputLine) < height {
@@ -153,6 +169,8 @@ func releaseRenderState(state *renderState) {
 
 	// Clear references to help GC
 	state.currentChar = nil
+	clear(state.outputRows)
+	state.outputRows = state.outputRows[:0]
 
 	// Shrink oversized buffers to prevent memory bloat
 	// These will be reallocated at appropriate size when needed
@@ -175,56 +193,53 @@ func releaseRenderState(state *renderState) {
 	renderStatePool.Put(state)
 }
 
-// acquireTempLine gets a temporary line buffer from the pool
-func acquireTempLine() []rune {
-	bufPtrInterface := tempLinePool.Get()
-	bufPtr, ok := bufPtrInterface.(*[]rune)
+// acquireTempLine gets a temporary line buffer from the pool. The pointer is
+// handed back to releaseTempLine as is, so pooling does not allocate.
+func acquireTempLine() *[]rune {
+	bufPtr, ok := tempLinePool.Get().(*[]rune)
 	if !ok {
 		// Fallback: allocate new buffer
 		buf := make([]rune, defaultOutlineLimit)
-		return buf
+		return &buf
 	}
-	buf := *bufPtr
 
 	// Clear the buffer
+	buf := *bufPtr
 	for i := range buf {
 		buf[i] = 0
 	}
 
-	return buf
+	return bufPtr
 }
 
 // releaseTempLine returns a temporary line buffer to the pool
-func releaseTempLine(buf []rune) {
-	if buf == nil || cap(buf) < defaultOutlineLimit {
+func releaseTempLine(bufPtr *[]rune) {
+	if bufPtr == nil || cap(*bufPtr) < defaultOutlineLimit {
 		return // Don't pool small or nil buffers
 	}
-	tempLinePool.Put(&buf)
+	tempLinePool.Put(bufPtr)
 }
 
-// acquireRuneSlice gets a rune slice from the pool
-func acquireRuneSlice() []rune {
-	bufPtrInterface := runeSlicePool.Get()
-	bufPtr, ok := bufPtrInterface.(*[]rune)
+// acquireRuneSlice gets an empty rune slice from the pool. Store the slice
+// back through the pointer if it grows, and hand the pointer to
+// releaseRuneSlice, so pooling does not allocate.
+func acquireRuneSlice() *[]rune {
+	bufPtr, ok := runeSlicePool.Get().(*[]rune)
 	if !ok {
 		// Fallback: allocate new buffer
 		buf := make([]rune, 0, 64)
-		return buf
+		return &buf
 	}
-	buf := *bufPtr
-	return buf[:0] // Reset length but keep capacity
+	*bufPtr = (*bufPtr)[:0] // Reset length but keep capacity
+	return bufPtr
 }
 
 // releaseRuneSlice returns a rune slice to the pool
-func releaseRuneSlice(buf []rune) {
-	if buf == nil || cap(buf) < 32 {
+func releaseRuneSlice(bufPtr *[]rune) {
+	if bufPtr == nil || cap(*bufPtr) < 32 {
 		return // Don't pool small buffers
 	}
-	// Clear references to help GC
-	for i := range buf {
-		buf[i] = 0
-	}
-	runeSlicePool.Put(&buf)
+	runeSlicePool.Put(bufPtr)
 }
 
 // acquireWriteBuffer gets a write buffer from the pool
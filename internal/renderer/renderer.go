@@ -40,6 +40,10 @@ func (state *renderState) initFromOptions(font *parser.Font, opts *Options) {
 	state.outlineLenLimit = 10000 // Default for golden test compatibility
 	if opts != nil {
 		state.trimWhitespace = opts.TrimWhitespace
+		state.paragraphMode = opts.ParagraphMode
+		state.wrapMode = opts.WrapMode
+		state.hyphenate = opts.Hyphenate
+		state.balancedWrap = opts.BalancedWrap
 		if opts.Width != nil && *opts.Width > 0 {
 			state.outlineLenLimit = *opts.Width - 1
 		}
@@ -52,6 +56,42 @@ func (state *renderState) initFromOptions(font *parser.Font, opts *Options) {
 	}
 
 	state.smushMode = resolveSmushMode(font, opts)
+
+	if opts != nil {
+		state.vertMode = opts.VerticalMode
+		state.vertRules = opts.VerticalRules
+		state.initJustify(opts)
+	}
+}
+
+// initJustify resolves the justification. Like FIGlet, lines are justified
+// within the output width, which defaults to 80 even though lines are not
+// wrapped unless a width is given.
+func (state *renderState) initJustify(opts *Options) {
+	state.justify = opts.Justify
+	if state.justify == JustifyAuto {
+		state.justify = JustifyLeft
+		if state.right2left == 1 {
+			state.justify = JustifyRight
+		}
+	}
+	state.justifyWidth = 80
+	if opts.Width != nil && *opts.Width > 0 {
+		state.justifyWidth = *opts.Width
+	}
+}
+
+// justifyPad returns the number of spaces to put before an output line of
+// the given length, as FIGlet's putstring does.
+func (state *renderState) justifyPad(lineLen int) int {
+	var pad int
+	switch state.justify {
+	case JustifyCenter:
+		pad = (state.justifyWidth - lineLen) / 2
+	case JustifyRight:
+		pad = state.justifyWidth - 1 - lineLen
+	}
+	return max(pad, 0)
 }
 
 // resolveSmushMode determines the smushing mode from font and options.
@@ -95,41 +135,158 @@ func (state *renderState) emitRenderStart(text string) time.Time {
 }
 
 // processText iterates over the input text and builds the rendered output.
+// When opts.InputFilter is set, the filtered character codes are rendered
+// instead of the text's UTF-8 runes.
 func (state *renderState) processText(text string, font *parser.Font, opts *Options) error {
-	for charIdx, r := range text {
-		if r == '\t' {
-			r = ' '
+	var err error
+	if state.balancedWrap {
+		err = state.processBalanced(text, font, opts)
+	} else {
+		err = state.processInput(text, font, opts)
+	}
+	if err != nil {
+		return err
+	}
+
+	// Flush any remaining line
+	if state.outlineLen > 0 {
+		state.emitSplit("end", 0, state.inputCount)
+		state.hardBreak = true
+		state.flushLine()
+	}
+	return nil
+}
+
+// processInput renders the input as it streams in, breaking lines greedily.
+func (state *renderState) processInput(text string, font *parser.Font, opts *Options) error {
+	if opts != nil && opts.InputFilter != nil {
+		runes := opts.InputFilter(text)
+		for charIdx, r := range runes {
+			if state.paragraphMode {
+				var next rune
+				if charIdx+1 < len(runes) {
+					next = runes[charIdx+1]
+				}
+				r = state.reflowRune(r, next)
+			}
+			state.source = charIdx
+			if err := state.processInputRune(r, charIdx, font, opts); err != nil {
+				return err
+			}
 		}
+		return nil
+	}
 
-		if r == '\n' {
-			state.handleNewline(charIdx)
-			continue
+	state.source = 0
+	for charIdx, r := range text {
+		if state.paragraphMode {
+			_, size := utf8.DecodeRuneInString(text[charIdx:])
+			next, _ := utf8.DecodeRuneInString(text[charIdx+size:])
+			r = state.reflowRune(r, next)
+		}
+		if err := state.processInputRune(r, charIdx, font, opts); err != nil {
+			return err
 		}
+		state.source++ // charIdx counts bytes; sources count runes
+	}
+	return nil
+}
 
-		if state.wordbreakmode == -1 && r == ' ' {
-			continue
+// processBalanced renders the whole input with balanced line breaks, which
+// need every word of a line before the first break can be chosen.
+func (state *renderState) processBalanced(text string, font *parser.Font, opts *Options) error {
+	var runes []rune
+	if opts != nil && opts.InputFilter != nil {
+		runes = opts.InputFilter(text)
+	} else {
+		runes = []rune(text)
+	}
+	if state.paragraphMode {
+		for i, r := range runes {
+			var next rune
+			if i+1 < len(runes) {
+				next = runes[i+1]
+			}
+			runes[i] = state.reflowRune(r, next)
 		}
-		if r < ' ' {
+	}
+
+	breaks, err := state.balanceLines(runes, font, opts)
+	if err != nil {
+		return err
+	}
+	for charIdx := 0; charIdx < len(runes); charIdx++ {
+		if len(breaks) > 0 && breaks[0].gap == charIdx {
+			// A chosen break: drop the spaces and wrap the line here rather
+			// than treating it as a newline from the input
+			state.emitSplit("wordbreak", 0, charIdx)
+			state.flushLine()
+			state.wordbreakmode = 0
+			charIdx = breaks[0].start - 1
+			breaks = breaks[1:]
 			continue
 		}
-
-		if err := state.processChar(r, charIdx, font, opts); err != nil {
+		state.source = charIdx
+		if err := state.processInputRune(runes[charIdx], charIdx, font, opts); err != nil {
 			return err
 		}
 	}
+	return nil
+}
 
-	// Flush any remaining line
-	if state.outlineLen > 0 {
-		state.emitSplit("end", 0, state.inputCount)
-		state.flushLine()
+// reflowRune applies paragraph mode to one input rune, given the rune after
+// it (0 at the end of the input). As in FIGlet's -p, a newline becomes a
+// space so the word-break FSM can reflow the paragraph, unless it follows
+// another newline or is followed by whitespace, which marks a blank line or
+// an indented line. Carriage returns are transparent, so CRLF input reflows.
+func (state *renderState) reflowRune(r, next rune) rune {
+	if r == '\r' {
+		return r
 	}
-	return nil
+	if r == '\n' && !state.lastWasEOL && !isParagraphSpace(next) {
+		r = ' '
+	}
+	state.lastWasEOL = r == '\n'
+	return r
+}
+
+// isParagraphSpace reports whether r is ASCII whitespace, as C's isspace.
+func isParagraphSpace(r rune) bool {
+	switch r {
+	case ' ', '\t', '\n', '\v', '\f', '\r':
+		return true
+	}
+	return false
+}
+
+// processInputRune handles a single input rune, dispatching newlines and
+// skipping control characters. Negative codes (reachable only through an
+// input filter) are passed through to glyph lookup.
+func (state *renderState) processInputRune(r rune, charIdx int, font *parser.Font, opts *Options) error {
+	if r == '\t' {
+		r = ' '
+	}
+
+	if r == '\n' {
+		state.handleNewline(charIdx)
+		return nil
+	}
+
+	if state.wordbreakmode == -1 && r == ' ' {
+		return nil
+	}
+	if r >= 0 && r < ' ' {
+		return nil
+	}
+
+	return state.processChar(r, charIdx, font, opts)
 }
 
 // handleNewline processes a newline character in the input.
 func (state *renderState) handleNewline(charIdx int) {
 	if state.outlineLen > 0 {
 		state.emitSplit("newline", 0, charIdx)
+		state.hardBreak = true
 		state.flushLine()
 	}
 	state.wordbreakmode = 0
@@ -178,11 +335,19 @@ func (state *renderState) processChar(r rune, charIdx int, font *parser.Font, op
 }
 
 // lookupGlyph finds the glyph for a rune, handling unknown rune substitution.
+//
+// Missing runes fall back to the font's own "missing character" (code 0) when
+// it defines one, matching C figlet, and only then to opts.UnknownRune. The
+// original rune is returned in the fallback case so word breaking and
+// re-rendering during splits see the input as typed.
 func (state *renderState) lookupGlyph(r rune, font *parser.Font, opts *Options) ([]string, rune, error) {
 	glyph, exists := font.Characters[r]
 	if exists {
 		return glyph, r, nil
 	}
+	if glyph, exists = font.Characters[parser.MissingCharCode]; exists {
+		return glyph, r, nil
+	}
 	if opts != nil && opts.UnknownRune != nil {
 		originalRune := r
 		r = *opts.UnknownRune
@@ -220,6 +385,9 @@ func (state *renderState) recordSuccess(r rune) {
 	} else {
 		state.inputBuffer[state.inputCount] = r
 	}
+	if state.grid != nil {
+		state.grid.recordSource(state.inputCount, state.source)
+	}
 
 	if r == ' ' {
 		state.lastWordBreak = state.inputCount
@@ -283,8 +451,15 @@ func (state *renderState) handleOversizedFirstChar(glyph []string, charIdx int)
 			copy(state.outputLine[row], runeSlice[:limit])
 			state.rowLengths[row] = limit
 		}
+		if g := state.grid; g != nil {
+			g.line[row] = g.line[row][:0]
+			for _, r := range state.outputLine[row][:state.rowLengths[row]] {
+				g.line[row] = append(g.line[row], Cell{Rune: r, Source: state.source})
+			}
+		}
 	}
 	state.outlineLen = state.rowLengths[0]
+	state.lineGlyphs = 1
 	state.emitSplit("width", -1, charIdx)
 	state.flushLine()
 	state.wordbreakmode = -1
@@ -306,15 +481,22 @@ func (state *renderState) handleSpaceFailure(font *parser.Font, opts *Options) {
 func (state *renderState) handleNonSpaceFailure(font *parser.Font, opts *Options) bool {
 	prevState := state.wordbreakmode
 
-	if state.wordbreakmode >= 2 {
-		if !state.splitLine(font, opts) {
-			state.flushLine()
-		}
-	} else {
+	switch {
+	case state.wrapMode == WrapWord && prevState == 1 && !state.overflowLine:
+		// The word fills the line on its own; let it run past the width
+		// rather than break it, and retry on this line
+		state.overflowLine = true
+		return true
+	case (prevState == 2 || prevState == 3 && state.wrapMode != WrapChar) && state.splitLine(font, opts):
+		// Broke at the last space; WrapChar only uses this to drop trailing spaces
+	case prevState == 1 || prevState == 3:
+		state.breakWord(font, opts)
+	default:
 		state.flushLine()
 	}
 
-	if prevState == 3 {
+	// Characters carried over by a split are the start of a word
+	if prevState == 3 || state.inputCount > 0 {
 		state.wordbreakmode = 1
 	} else {
 		state.wordbreakmode = 0
@@ -322,8 +504,63 @@ func (state *renderState) handleNonSpaceFailure(font *parser.Font, opts *Options
 	return true
 }
 
+// breakWord ends the current line inside a word, at a glyph boundary. With
+// hyphenation on, trailing characters are carried over to the next line
+// until the font's '-' glyph fits after the rest, or the rest already ends
+// with a '-'.
+func (state *renderState) breakWord(font *parser.Font, opts *Options) {
+	count := state.inputCount
+	keep := count
+
+	hyphen, hasHyphen := font.Characters['-']
+	if state.hyphenate && hasHyphen {
+		keep = 0
+		for k := count; k > 0 && state.inputBuffer[k-1] != ' '; k-- {
+			state.clearOutputLine()
+			if n, err := state.renderCharacterRange(font, 0, k, opts); err != nil || n != k {
+				continue
+			}
+			if state.inputBuffer[k-1] == '-' || state.addHyphen(hyphen) {
+				keep = k
+				break
+			}
+		}
+		if keep == 0 {
+			// No room for a hyphen after any part of the word
+			keep = count
+			state.clearOutputLine()
+			_, _ = state.renderCharacterRange(font, 0, count, opts)
+		}
+	}
+
+	state.emitSplit("char", 1, keep)
+	state.flushLine()
+
+	// Carry the rest of the word over to the new line
+	if keep < count {
+		copy(state.inputBuffer, state.inputBuffer[keep:count])
+		if state.grid != nil {
+			copy(state.grid.sources, state.grid.sources[keep:count])
+		}
+		rendered, err := state.renderCharacterRange(font, 0, count-keep, opts)
+		if err == nil {
+			state.inputCount = rendered
+		}
+	}
+}
+
+// addHyphen adds the font's '-' glyph at a break inside a word. It is not
+// part of the input, so its cells have no source.
+func (state *renderState) addHyphen(hyphen []string) bool {
+	source := state.source
+	state.source = -1
+	defer func() { state.source = source }()
+	return state.addChar(hyphen)
+}
+
 // writeOutput writes the accumulated render output to the writer.
 func (state *renderState) writeOutput(w io.Writer, text string, startTime time.Time, fontHeight int) error {
+	state.writeRows()
 	if len(state.outputBuffer) > 0 {
 		if state.outputBuffer[len(state.outputBuffer)-1] == '\n' {
 			state.outputBuffer = state.outputBuffer[:len(state.outputBuffer)-1]
@@ -512,19 +749,26 @@ func (state *renderState) addChar(glyph []string) bool {
 		smushAmt = 0
 	}
 
-	if state.outlineLen+state.currentCharWidth-smushAmt > state.outlineLenLimit {
+	limit := state.outlineLenLimit
+	if state.overflowLine && !state.processingSpaceGlyph {
+		// A WrapWord word wider than the line; spaces still break it
+		limit = defaultOutlineLimit
+	}
+	if state.outlineLen+state.currentCharWidth-smushAmt > limit {
 		return false
 	}
 
 	// Get pooled buffer for rune conversion
-	runeBuffer := acquireRuneSlice()
-	defer releaseRuneSlice(runeBuffer)
+	runeBufferPtr := acquireRuneSlice()
+	defer releaseRuneSlice(runeBufferPtr)
+	runeBuffer := *runeBufferPtr
 
 	// Get temp buffer from pool for RTL processing
 	var tempLine []rune
 	if state.right2left != 0 {
-		tempLine = acquireTempLine()
-		defer releaseTempLine(tempLine)
+		tempLinePtr := acquireTempLine()
+		defer releaseTempLine(tempLinePtr)
+		tempLine = *tempLinePtr
 	}
 
 	for row := 0; row < state.charHeight; row++ {
@@ -537,8 +781,10 @@ func (state *renderState) addChar(glyph []string) bool {
 			state.addCharRowLTR(row, rowRunes, smushAmt)
 		}
 	}
+	*runeBufferPtr = runeBuffer // keep any growth for the next caller
 
 	state.outlineLen = state.rowLengths[0]
+	state.lineGlyphs++
 	return true
 }
 
@@ -547,6 +793,14 @@ func (state *renderState) addCharRowRTL(row int, rowRunes, tempLine []rune, smus
 	end := state.rowLengths[row]
 	copy(tempLine, rowRunes)
 
+	g := state.grid
+	if g != nil {
+		g.temp = g.temp[:0]
+		for _, r := range tempLine[:max(len(rowRunes), state.currentCharWidth)] {
+			g.temp = append(g.temp, Cell{Rune: r, Source: state.source})
+		}
+	}
+
 	// Apply smushing at overlap positions
 	for k := 0; k < smushAmt; k++ {
 		column := state.currentCharWidth - smushAmt + k
@@ -561,6 +815,13 @@ func (state *renderState) addCharRowRTL(row int, rowRunes, tempLine []rune, smus
 			if smushResult != 0 {
 				state.emitSmushDecision(row, column, left, existing, smushResult)
 				tempLine[column] = smushResult
+				if g != nil {
+					right := blankCell
+					if k < end {
+						right = g.line[row][k]
+					}
+					g.temp = setCell(g.temp, column, state.smushedCell(g.temp[column], right, smushResult))
+				}
 			} else {
 				tempLine[column] = 0 // Mark for truncation
 			}
@@ -581,6 +842,9 @@ func (state *renderState) addCharRowRTL(row int, rowRunes, tempLine []rune, smus
 	if smushAmt < end && tempEnd == state.currentCharWidth {
 		for i := smushAmt; i < end; i++ {
 			tempLine[tempEnd] = state.outputLine[row][i]
+			if g != nil {
+				g.temp = setCell(g.temp, tempEnd, g.line[row][i])
+			}
 			tempEnd++
 		}
 		state.emitRowAppend(row, appendStart, end-smushAmt, appendStart, tempEnd)
@@ -588,6 +852,9 @@ func (state *renderState) addCharRowRTL(row int, rowRunes, tempLine []rune, smus
 
 	copy(state.outputLine[row][:tempEnd], tempLine[:tempEnd])
 	state.rowLengths[row] = tempEnd
+	if g != nil {
+		g.line[row] = append(g.line[row][:0], fitCells(g.temp, tempEnd)...)
+	}
 }
 
 // addCharRowLTR processes a single row for left-to-right character addition.
@@ -609,6 +876,14 @@ func (state *renderState) addCharRowLTR(row int, rowRunes []rune, smushAmt int)
 			smushResult := state.smush(existing, rowRunes[k])
 			if smushResult != 0 {
 				state.outputLine[row][column] = smushResult
+				if g := state.grid; g != nil {
+					left := Cell{Rune: existing, Source: -1}
+					if column < end {
+						left = g.line[row][column]
+					}
+					right := Cell{Rune: rowRunes[k], Source: state.source}
+					g.line[row] = setCell(g.line[row], column, state.smushedCell(left, right, smushResult))
+				}
 				if column >= end {
 					end = column + 1
 				}
@@ -623,11 +898,20 @@ func (state *renderState) addCharRowLTR(row int, rowRunes []rune, smushAmt int)
 		remaining := rowRunes[smushAmt:]
 		startPos := end
 		copy(state.outputLine[row][end:], remaining)
+		if g := state.grid; g != nil {
+			g.line[row] = fitCells(g.line[row], startPos)
+			for _, r := range remaining {
+				g.line[row] = append(g.line[row], Cell{Rune: r, Source: state.source})
+			}
+		}
 		end += len(remaining)
 		state.emitRowAppend(row, startPos, len(remaining), startPos, end)
 	}
 
 	state.rowLengths[row] = end
+	if g := state.grid; g != nil {
+		g.line[row] = fitCells(g.line[row], end)
+	}
 }
 
 // emitSmushDecision emits a debug event for a smushing decision.
@@ -692,9 +976,10 @@ func (state *renderState) writeTo(w io.Writer) error {
 		buf = make([]byte, 0, 256)
 	}
 
+	pad := state.justifyPad(state.outlineLen)
 	for i, line := range state.outputLine {
 		var err error
-		buf, err = state.writeRow(w, buf, line[:state.rowLengths[i]])
+		buf, err = state.writeRow(w, buf, pad, line[:state.rowLengths[i]])
 		if err != nil {
 			return err
 		}
@@ -710,9 +995,10 @@ func (state *renderState) writeTo(w io.Writer) error {
 	return nil
 }
 
-// writeRow writes a single row of output to w, replacing hardblanks and optionally trimming.
+// writeRow writes a single row of output to w after pad spaces of justification,
+// replacing hardblanks and optionally trimming.
 // It reuses buf for UTF-8 encoding and returns the (possibly reallocated) buffer.
-func (state *renderState) writeRow(w io.Writer, buf []byte, actualLine []rune) ([]byte, error) {
+func (state *renderState) writeRow(w io.Writer, buf []byte, pad int, actualLine []rune) ([]byte, error) {
 	lastNonSpace := len(actualLine) - 1
 	if state.trimWhitespace {
 		for lastNonSpace >= 0 && actualLine[lastNonSpace] == ' ' {
@@ -721,6 +1007,11 @@ func (state *renderState) writeRow(w io.Writer, buf []byte, actualLine []rune) (
 	}
 
 	buf = buf[:0]
+	if lastNonSpace >= 0 {
+		for ; pad > 0; pad-- {
+			buf = append(buf, ' ')
+		}
+	}
 	for j := 0; j <= lastNonSpace; j++ {
 		r := actualLine[j]
 		if r == state.hardblank {
@@ -786,46 +1077,47 @@ func (state *renderState) flushLine() {
 		copy(rowLengthsBefore, state.rowLengths[:limit])
 	}
 
-	// Get buffer from pool for UTF-8 encoding
-	buf := acquireWriteBuffer()
-	defer releaseWriteBuffer(buf)
+	// Every row of the line gets the same padding so the rows stay aligned
+	pad := state.justifyPad(state.outlineLen)
 
-	// Ensure buffer has reasonable capacity
-	if cap(buf) < 256 {
-		buf = make([]byte, 0, 256)
+	if state.metrics != nil {
+		state.recordLine(pad)
+	}
+	if state.grid != nil {
+		state.flushGrid(pad)
 	}
 
-	// Process each row of the current line
-	for i := 0; i < state.charHeight; i++ {
-		// Extract only the actual content using row-specific length
-		actualLine := state.outputLine[i][:state.rowLengths[i]]
-
-		// Process the line, replacing hardblanks and optionally trimming
-		lastNonSpace := len(actualLine) - 1
-		if state.trimWhitespace {
-			// Find last non-space character
-			for lastNonSpace >= 0 && actualLine[lastNonSpace] == ' ' {
-				lastNonSpace--
+	switch {
+	case state.vertMode != VertFull:
+		// Keep the rows, hardblanks included, so the next line can overlap them
+		block := make([][]rune, state.charHeight)
+		for i := range block {
+			row := make([]rune, pad, pad+state.rowLengths[i])
+			for j := range row {
+				row[j] = ' '
 			}
+			block[i] = append(row, state.outputLine[i][:state.rowLengths[i]]...)
 		}
+		state.appendBlock(block)
+	case state.metrics != nil || state.grid != nil:
+		// Measuring or building a grid; the rows are not encoded
+	default:
+		// Get buffer from pool for UTF-8 encoding
+		buf := acquireWriteBuffer()
+		defer releaseWriteBuffer(buf)
 
-		// Write runes to buffer, replacing hardblanks
-		buf = buf[:0] // Reset buffer
-		for j := 0; j <= lastNonSpace; j++ {
-			r := actualLine[j]
-			if r == state.hardblank {
-				r = ' '
-			}
-			// Append rune to buffer
-			buf = utf8.AppendRune(buf, r)
+		// Ensure buffer has reasonable capacity
+		if cap(buf) < 256 {
+			buf = make([]byte, 0, 256)
 		}
 
-		// Append to output buffer
-		state.outputBuffer = append(state.outputBuffer, buf...)
-
-		// Add newline after each row
-		state.outputBuffer = append(state.outputBuffer, '\n')
+		// Process each row of the current line
+		for i := 0; i < state.charHeight; i++ {
+			// Extract only the actual content using row-specific length
+			buf = state.appendRow(buf, pad, state.outputLine[i][:state.rowLengths[i]])
+		}
 	}
+	state.hardBreak = false
 
 	// Capture row lengths after reset (all zeros)
 	var rowLengthsAfter []int
@@ -850,6 +1142,57 @@ func (state *renderState) flushLine() {
 	state.resetLine()
 }
 
+// writeRows moves the rows accumulated for vertical layout to the output buffer.
+func (state *renderState) writeRows() {
+	if len(state.outputRows) == 0 {
+		return
+	}
+
+	buf := acquireWriteBuffer()
+	defer releaseWriteBuffer(buf)
+
+	for _, row := range state.outputRows {
+		buf = state.appendRow(buf, 0, row)
+	}
+	state.outputRows = state.outputRows[:0]
+}
+
+// appendRow appends one output row and its newline to the output buffer,
+// after pad spaces of justification, replacing hardblanks and optionally
+// trimming. It reuses buf for UTF-8 encoding and returns the (possibly
+// reallocated) buffer.
+func (state *renderState) appendRow(buf []byte, pad int, actualLine []rune) []byte {
+	// Process the line, replacing hardblanks and optionally trimming
+	lastNonSpace := len(actualLine) - 1
+	if state.trimWhitespace {
+		// Find last non-space character
+		for lastNonSpace >= 0 && actualLine[lastNonSpace] == ' ' {
+			lastNonSpace--
+		}
+	}
+
+	// Write runes to buffer, replacing hardblanks
+	buf = buf[:0] // Reset buffer
+	if lastNonSpace >= 0 {
+		for ; pad > 0; pad-- {
+			buf = append(buf, ' ')
+		}
+	}
+	for j := 0; j <= lastNonSpace; j++ {
+		r := actualLine[j]
+		if r == state.hardblank {
+			r = ' '
+		}
+		// Append rune to buffer
+		buf = utf8.AppendRune(buf, r)
+	}
+
+	// Append to output buffer, with a newline after each row
+	state.outputBuffer = append(state.outputBuffer, buf...)
+	state.outputBuffer = append(state.outputBuffer, '\n')
+	return buf
+}
+
 // clearOutputLine clears just the output line buffer without resetting other state.
 // This is used during word wrapping to re-render from a specific point.
 func (state *renderState) clearOutputLine() {
@@ -862,10 +1205,15 @@ func (state *renderState) clearOutputLine() {
 		state.rowLengths[i] = 0
 	}
 
+	if state.grid != nil {
+		state.grid.clearLine()
+	}
+
 	// Reset output tracking
 	state.outlineLen = 0
 	state.previousCharWidth = 0
 	state.currentCharWidth = 0
+	state.lineGlyphs = 0
 }
 
 // resetLine clears the current output line for the next line of text.
@@ -879,14 +1227,20 @@ func (state *renderState) resetLine() {
 		state.rowLengths[i] = 0
 	}
 
+	if state.grid != nil {
+		state.grid.clearLine()
+	}
+
 	// Reset line tracking
 	state.outlineLen = 0
 	state.previousCharWidth = 0
 	state.currentCharWidth = 0
+	state.lineGlyphs = 0
 
 	// Reset input line tracking
 	state.inputCount = 0
 	state.lastWordBreak = -1
+	state.overflowLine = false
 }
 
 // renderCharacterRange renders a specific range of characters from inputBuffer.
@@ -898,29 +1252,27 @@ func (state *renderState) renderCharacterRange(font *parser.Font, start, end int
 		return 0, nil
 	}
 
+	// Cells of re-rendered characters belong to their own input runes
+	source := state.source
+	defer func() { state.source = source }()
+
 	renderedCount := 0
 	// Render each character in the range
 	for i := start; i < end; i++ {
 		r := state.inputBuffer[i]
+		if state.grid != nil {
+			state.source = state.grid.sources[i]
+		}
 
 		// Skip newlines during re-rendering
 		if r == '\n' {
 			continue
 		}
 
-		// Get character glyph
-		glyph, exists := font.Characters[r]
-		if !exists {
-			// Handle unknown character
-			if opts != nil && opts.UnknownRune != nil {
-				r = *opts.UnknownRune
-				glyph, exists = font.Characters[r]
-				if !exists {
-					return renderedCount, fmt.Errorf("%w: %s", ErrUnsupportedRune, string(r))
-				}
-			} else {
-				return renderedCount, fmt.Errorf("%w: %s", ErrUnsupportedRune, string(r))
-			}
+		// Get character glyph, applying the same fallbacks as the main pass
+		glyph, r, err := state.lookupGlyph(r, font, opts)
+		if err != nil {
+			return renderedCount, err
 		}
 
 		// Track when processing a space character - spaces should not
@@ -1003,6 +1355,9 @@ func (state *renderState) splitLine(font *parser.Font, opts *Options) bool {
 	if lastSpaceEnd < savedInputCount {
 		remainingCount := savedInputCount - lastSpaceEnd
 		copy(state.inputBuffer[0:], state.inputBuffer[lastSpaceEnd:savedInputCount])
+		if state.grid != nil {
+			copy(state.grid.sources, state.grid.sources[lastSpaceEnd:savedInputCount])
+		}
 
 		// Re-render the remainder on new line and get actual rendered count
 		renderedCount, err := state.renderCharacterRange(font, 0, remainingCount, opts)
@@ -213,8 +213,9 @@ func (state *renderState) smushAmount() int {
 	}
 
 	// Get a pooled rune buffer for conversions
-	runeBuffer := acquireRuneSlice()
-	defer releaseRuneSlice(runeBuffer)
+	runeBufferPtr := acquireRuneSlice()
+	defer releaseRuneSlice(runeBufferPtr)
+	runeBuffer := *runeBufferPtr
 
 	maxSmush := state.currentCharWidth
 
@@ -255,6 +256,7 @@ func (state *renderState) smushAmount() int {
 		}
 	}
 
+	*runeBufferPtr = runeBuffer // keep any growth for the next caller
 	return maxSmush
 }
 
@@ -32,6 +32,21 @@ const (
 	SMHardblank = 32 // Hardblank rule
 )
 
+// Wrap mode constants, matching the order of figgo.WrapMode
+const (
+	WrapWordThenChar = iota // Break at spaces, and inside words wider than a line
+	WrapWord                // Break only at spaces; longer words run past the width
+	WrapChar                // Break at any glyph boundary
+)
+
+// Justification constants, matching the order of figgo.Justify
+const (
+	JustifyLeft   = iota // Lines start at column 0
+	JustifyCenter        // Lines are centred within the width
+	JustifyRight         // Lines end at the width
+	JustifyAuto          // Right for right-to-left text, left otherwise
+)
+
 // Options contains rendering options passed from the main package
 type Options struct {
 	// Layout is the layout bitmask from figgo.Layout
@@ -46,6 +61,26 @@ type Options struct {
 	Width *int
 	// Debug is the debug session for tracing
 	Debug *debug.Session
+	// InputFilter, when set, converts the input text into the character codes
+	// to render (e.g. FIGlet control file decoding and translation)
+	InputFilter func(text string) []rune
+	// VerticalMode is how output lines are stacked (VertFull, VertFitting,
+	// VertSmushControlled or VertSmushUniversal)
+	VerticalMode int
+	// VerticalRules holds the VSM* rule bits for VertSmushControlled
+	VerticalRules int
+	// Justify aligns each output line within Width (JustifyLeft etc.)
+	Justify int
+	// WrapMode is how lines are broken at Width (WrapWordThenChar etc.)
+	WrapMode int
+	// Hyphenate ends lines broken inside a word with the font's '-' glyph
+	Hyphenate bool
+	// BalancedWrap chooses line breaks that make the lines as even as
+	// possible instead of filling each line in turn
+	BalancedWrap bool
+	// ParagraphMode joins lines like FIGlet's -p: a newline is a space
+	// unless it is followed by whitespace or follows another newline
+	ParagraphMode bool
 }
 
 // renderState holds the current rendering state.
@@ -60,6 +95,9 @@ type renderState struct {
 	// String builder for accumulated output
 	outputBuffer []byte // Accumulated output from completed lines
 
+	// Completed rows, kept unencoded when lines are stacked with vertical fitting or smushing
+	outputRows [][]rune
+
 	// int fields (8 bytes each on 64-bit)
 	outlineLen        int // Length of current output line
 	outlineLenLimit   int // Maximum line length allowed
@@ -71,6 +109,13 @@ type renderState struct {
 	inputCount        int // Count of characters in input buffer
 	lastWordBreak     int // Position of last space/word boundary in inputBuffer
 	wordbreakmode     int // State machine for line breaking
+	vertMode          int // Vertical layout mode (VertFull etc.)
+	vertRules         int // Vertical smushing rules (VSM* bits)
+	wrapMode          int // How lines are broken at the width (WrapWordThenChar etc.)
+	justify           int // Resolved justification (JustifyLeft, JustifyCenter or JustifyRight)
+	justifyWidth      int // Width output lines are justified within
+	lineGlyphs        int // Glyphs added to the current output line
+	source            int // Input index of the rune being added, for the cell grid
 
 	// rune field (4 bytes)
 	hardblank rune // Hardblank character from font
@@ -78,7 +123,19 @@ type renderState struct {
 	// bool fields (1 byte each)
 	trimWhitespace       bool // Whether to trim trailing whitespace
 	processingSpaceGlyph bool // True when processing space character glyph
+	paragraphMode        bool // Whether single newlines are reflowed as spaces
+	hyphenate            bool // Whether words broken across lines get a hyphen
+	balancedWrap         bool // Whether line breaks are balanced rather than greedy
+	overflowLine         bool // True when WrapWord lets the current word run past the width
+	hardBreak            bool // True when the line being flushed ends at a newline or the end of input
+	lastWasEOL           bool // True when the previous input rune was a kept newline
 
 	// Debug session for tracing
 	debug *debug.Session
+
+	// Metrics collected instead of output when measuring; nil when rendering
+	metrics *Metrics
+
+	// Cell grid built instead of output by RenderGrid; nil otherwise
+	grid *gridState
 }
@@ -0,0 +1,166 @@
+package renderer
+
+// Vertical layout modes, matching the order of figgo.AxisMode
+const (
+	// VertFull stacks output lines at full height
+	VertFull = iota
+	// VertFitting moves each output line up until it would touch the one above
+	VertFitting
+	// VertSmushControlled overlaps output lines by one more row, merging
+	// touching characters with the vertical rules
+	VertSmushControlled
+	// VertSmushUniversal overlaps output lines by one more row, letting the
+	// lower line's characters win
+	VertSmushUniversal
+)
+
+// Vertical smushing rule bits, matching FullLayout bits 8-12 shifted down by 8
+const (
+	VSMEqual          = 1  // Vertical equal character rule
+	VSMLowline        = 2  // Vertical underscore rule
+	VSMHierarchy      = 4  // Vertical hierarchy rule
+	VSMHorizontalLine = 8  // Horizontal line rule: "-" and "_" make "="
+	VSMVerticalLine   = 16 // Vertical line supersmushing: "|" over "|"
+)
+
+// rowFit is the result of overlapping one pair of rows.
+type rowFit int
+
+const (
+	rowFitValid   rowFit = iota // no collisions; the overlap may grow
+	rowFitEnd                   // collisions merged; the overlap may not grow
+	rowFitInvalid               // collisions that cannot be merged
+)
+
+// appendBlock adds a flushed output line to the accumulated rows, overlapping
+// it with the rows above according to the vertical layout. It matches the
+// FIGfont 2 specification's vertical fitting and smushing as implemented by
+// FIGWin and figlet.js; FIGlet 2.2 itself always stacks at full height.
+func (state *renderState) appendBlock(block [][]rune) {
+	overlap := state.verticalOverlap(state.outputRows, block)
+	top := len(state.outputRows) - overlap
+	upper := state.outputRows[top:]
+	merged := make([][]rune, overlap)
+	for i := range merged {
+		merged[i] = state.verticalSmushRow(upper[i], block[i])
+	}
+	if state.grid != nil {
+		state.grid.appendBlock(top, upper, block, merged)
+	}
+	copy(upper, merged)
+	state.outputRows = append(state.outputRows, block[overlap:]...)
+}
+
+// verticalOverlap returns how many rows of lower can overlap the bottom of
+// upper. It grows the overlap one row at a time while every row pair fits,
+// and stops at the first overlap whose collisions had to be merged.
+func (state *renderState) verticalOverlap(upper, lower [][]rune) int {
+	if state.vertMode == VertFull {
+		return 0
+	}
+	maxOverlap := min(len(upper), len(lower))
+
+	overlap := 0
+	for dist := 1; dist <= maxOverlap; dist++ {
+		result := rowFitValid
+		for i := 0; i < dist; i++ {
+			switch state.verticalRowFit(upper[len(upper)-dist+i], lower[i]) {
+			case rowFitInvalid:
+				return overlap
+			case rowFitEnd:
+				result = rowFitEnd
+			}
+		}
+		overlap = dist
+		if result == rowFitEnd {
+			break
+		}
+	}
+	return overlap
+}
+
+// verticalRowFit reports whether the row bottom can be placed over the row top.
+func (state *renderState) verticalRowFit(top, bottom []rune) rowFit {
+	result := rowFitValid
+	for i := 0; i < min(len(top), len(bottom)); i++ {
+		tch, bch := top[i], bottom[i]
+		if tch == ' ' || bch == ' ' {
+			continue
+		}
+		switch state.vertMode {
+		case VertFitting:
+			return rowFitInvalid
+		case VertSmushUniversal:
+			return rowFitEnd
+		}
+		// Vertical line supersmushing lets the overlap keep growing
+		if state.vertRules&VSMVerticalLine != 0 && tch == '|' && bch == '|' {
+			continue
+		}
+		if verticalSmushControlled(tch, bch, state.vertRules&^VSMVerticalLine) == 0 {
+			return rowFitInvalid
+		}
+		result = rowFitEnd
+	}
+	return result
+}
+
+// verticalSmushRow merges the row bottom into the row top.
+func (state *renderState) verticalSmushRow(top, bottom []rune) []rune {
+	merged := make([]rune, max(len(top), len(bottom)))
+	for i := range merged {
+		tch, bch := ' ', ' '
+		if i < len(top) {
+			tch = top[i]
+		}
+		if i < len(bottom) {
+			bch = bottom[i]
+		}
+
+		switch {
+		case tch == ' ' || bch == ' ' || state.vertMode != VertSmushControlled:
+			merged[i] = state.verticalSmushUniversal(tch, bch)
+		default:
+			merged[i] = verticalSmushControlled(tch, bch, state.vertRules)
+			if merged[i] == 0 {
+				// Unreachable after verticalOverlap; keep the lower character
+				merged[i] = bch
+			}
+		}
+	}
+	return merged
+}
+
+// verticalSmushUniversal returns the lower character unless it is blank, or a
+// hardblank over a visible character.
+func (state *renderState) verticalSmushUniversal(tch, bch rune) rune {
+	if bch == ' ' || (bch == state.hardblank && tch != ' ') {
+		return tch
+	}
+	return bch
+}
+
+// verticalSmushControlled applies the vertical smushing rules in order.
+// Returns 0 if no rule applies.
+func verticalSmushControlled(tch, bch rune, rules int) rune {
+	if rules&VSMEqual != 0 && tch == bch {
+		return tch
+	}
+	if rules&VSMLowline != 0 {
+		if r := smushUnderscore(tch, bch, SMLowline); r != 0 {
+			return r
+		}
+	}
+	if rules&VSMHierarchy != 0 {
+		if r := smushHierarchy(tch, bch, SMHierarchy); r != 0 {
+			return r
+		}
+	}
+	if rules&VSMHorizontalLine != 0 && (tch == '-' && bch == '_' || tch == '_' && bch == '-') {
+		return '='
+	}
+	if rules&VSMVerticalLine != 0 && tch == '|' && bch == '|' {
+		return '|'
+	}
+	return 0
+}
@@ -0,0 +1,159 @@
+package renderer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ryanlewis/figgo/internal/parser"
+)
+
+// runeRows converts rows of text to rune rows.
+func runeRows(rows ...string) [][]rune {
+	out := make([][]rune, len(rows))
+	for i, row := range rows {
+		out[i] = []rune(row)
+	}
+	return out
+}
+
+func TestVerticalSmushControlled(t *testing.T) {
+	const all = VSMEqual | VSMLowline | VSMHierarchy | VSMHorizontalLine | VSMVerticalLine
+
+	tests := []struct {
+		name  string
+		top   rune
+		bot   rune
+		rules int
+		want  rune
+	}{
+		{name: "equal", top: '#', bot: '#', rules: VSMEqual, want: '#'},
+		{name: "equal disabled", top: '#', bot: '#', rules: VSMLowline, want: 0},
+		{name: "underscore over bracket", top: '_', bot: '[', rules: VSMLowline, want: '['},
+		{name: "slash over underscore", top: '/', bot: '_', rules: VSMLowline, want: '/'},
+		{name: "hierarchy", top: '|', bot: '/', rules: VSMHierarchy, want: '/'},
+		{name: "hierarchy same class", top: '/', bot: '\\', rules: VSMHierarchy, want: 0},
+		{name: "dash over underscore", top: '-', bot: '_', rules: VSMHorizontalLine, want: '='},
+		{name: "underscore over dash", top: '_', bot: '-', rules: VSMHorizontalLine, want: '='},
+		{name: "vertical line", top: '|', bot: '|', rules: VSMVerticalLine, want: '|'},
+		{name: "no rule applies", top: 'A', bot: 'B', rules: all, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := verticalSmushControlled(tt.top, tt.bot, tt.rules); got != tt.want {
+				t.Errorf("verticalSmushControlled(%q, %q) = %q, want %q", tt.top, tt.bot, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAppendBlock(t *testing.T) {
+	// upper is the block already output in most cases
+	upper := []string{"AAA", "A A", "   "}
+
+	tests := []struct {
+		name  string
+		mode  int
+		rules int
+		upper []string
+		lower []string
+		want  []string
+	}{
+		{
+			name:  "full height",
+			mode:  VertFull,
+			lower: []string{"BBB", "   "},
+			want:  []string{"AAA", "A A", "   ", "BBB", "   "},
+		},
+		{
+			name:  "fitting stops before touching",
+			mode:  VertFitting,
+			lower: []string{" B ", "BBB"},
+			want:  []string{"AAA", "ABA", "BBB"},
+		},
+		{
+			name:  "fitting without room",
+			mode:  VertFitting,
+			lower: []string{"BBB"},
+			want:  []string{"AAA", "A A", "BBB"},
+		},
+		{
+			name:  "universal smushing overlaps one more row",
+			mode:  VertSmushUniversal,
+			lower: []string{"B B", "BBB"},
+			want:  []string{"AAA", "B B", "BBB"},
+		},
+		{
+			name:  "controlled smushing merges with rules",
+			mode:  VertSmushControlled,
+			rules: VSMHorizontalLine,
+			upper: []string{"AAA", "- -"},
+			lower: []string{"_ _", "BBB"},
+			want:  []string{"AAA", "= =", "BBB"},
+		},
+		{
+			name:  "controlled smushing falls back to fitting",
+			mode:  VertSmushControlled,
+			rules: VSMEqual,
+			lower: []string{"B B", "BBB"},
+			want:  []string{"AAA", "A A", "B B", "BBB"},
+		},
+		{
+			name:  "vertical line supersmushing keeps overlapping",
+			mode:  VertSmushControlled,
+			rules: VSMVerticalLine,
+			upper: []string{"| |", "| |"},
+			lower: []string{"| |", "| |", "| |"},
+			want:  []string{"| |", "| |", "| |"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state := &renderState{vertMode: tt.mode, vertRules: tt.rules, hardblank: '$'}
+			if tt.upper == nil {
+				tt.upper = upper
+			}
+			state.appendBlock(runeRows(tt.upper...))
+			state.appendBlock(runeRows(tt.lower...))
+
+			got := make([]string, len(state.outputRows))
+			for i, row := range state.outputRows {
+				got[i] = string(row)
+			}
+			if strings.Join(got, "\n") != strings.Join(tt.want, "\n") {
+				t.Errorf("rows:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(tt.want, "\n"))
+			}
+		})
+	}
+}
+
+func TestRenderVerticalLayout(t *testing.T) {
+	glyphs := map[rune][]string{' ': {" ", " ", " "}}
+	for r := rune(33); r <= 126; r++ {
+		glyphs[r] = []string{"_", "|", " "}
+	}
+	font := &parser.Font{Height: 3, Hardblank: '$', Characters: glyphs, OldLayout: -1}
+
+	tests := []struct {
+		name string
+		mode int
+		want string
+	}{
+		{name: "full", mode: VertFull, want: "_\n|\n \n_\n|\n "},
+		{name: "fitting", mode: VertFitting, want: "_\n|\n_\n|\n "},
+		{name: "universal", mode: VertSmushUniversal, want: "_\n_\n|\n "},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Render("a\nb", font, &Options{Layout: 0, VerticalMode: tt.mode})
+			if err != nil {
+				t.Fatalf("Render() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Render() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package renderer
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/ryanlewis/figgo/internal/debug"
+	"github.com/ryanlewis/figgo/internal/parser"
+)
+
+// textFont returns a one-row full-width font whose glyphs are the characters
+// themselves, so rendered output reads like the wrapped input.
+func textFont() *parser.Font {
+	glyphs := make(map[rune][]string)
+	for r := rune(32); r <= 126; r++ {
+		glyphs[r] = []string{string(r)}
+	}
+	return &parser.Font{Height: 1, Hardblank: '$', Characters: glyphs, OldLayout: -1}
+}
+
+func TestRenderWrapMode(t *testing.T) {
+	font := textFont()
+	width := 6 // lines hold 5 characters
+
+	tests := []struct {
+		name      string
+		text      string
+		mode      int
+		hyphenate bool
+		want      string
+	}{
+		{name: "word then char", text: "ab cdefgh", mode: WrapWordThenChar, want: "ab\ncdefg\nh"},
+		{name: "word then char hyphenated", text: "ab cdefgh", mode: WrapWordThenChar, hyphenate: true, want: "ab\ncdef-\ngh"},
+		{name: "word keeps long words whole", text: "ab cdefgh ij", mode: WrapWord, want: "ab\ncdefgh\nij"},
+		{name: "word ignores hyphenation", text: "ab cdefgh", mode: WrapWord, hyphenate: true, want: "ab\ncdefgh"},
+		{name: "char", text: "ab cdefgh", mode: WrapChar, want: "ab cd\nefgh"},
+		{name: "char hyphenated", text: "ab cdefgh", mode: WrapChar, hyphenate: true, want: "ab c-\ndefgh"},
+		{name: "char drops trailing spaces", text: "abcd  efg", mode: WrapChar, want: "abcd\nefg"},
+		{name: "no extra hyphen after a hyphen", text: "abcd-efgh", mode: WrapChar, hyphenate: true, want: "abcd-\nefgh"},
+		{name: "short text is not wrapped", text: "ab cd", mode: WrapChar, hyphenate: true, want: "ab cd"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Render(tt.text, font, &Options{Width: &width, WrapMode: tt.mode, Hyphenate: tt.hyphenate})
+			if err != nil {
+				t.Fatalf("Render() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Render() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderWrapModeSplitEvent(t *testing.T) {
+	debug.SetEnabled(true)
+	defer debug.SetEnabled(false)
+
+	var buf bytes.Buffer
+	session := debug.NewSession(debug.NewJSONSink(&buf))
+	width := 6
+	if _, err := Render("abcdefgh", textFont(), &Options{Width: &width, WrapMode: WrapChar, Debug: session}); err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	session.Close()
+
+	if !strings.Contains(buf.String(), `"reason":"char"`) {
+		t.Errorf("no Split event with reason char in:\n%s", buf.String())
+	}
+}
@@ -0,0 +1,84 @@
+package figgo
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestWithJustify(t *testing.T) {
+	font, err := LoadFont("fonts/standard.flf")
+	if err != nil {
+		t.Fatalf("LoadFont() error = %v", err)
+	}
+	const text = "Hi"
+
+	left, err := Render(text, font, WithWidth(40))
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	explicitLeft, err := Render(text, font, WithWidth(40), WithJustify(JustifyLeft))
+	if err != nil {
+		t.Fatalf("Render(JustifyLeft) error = %v", err)
+	}
+	if explicitLeft != left {
+		t.Errorf("JustifyLeft output differs from the default:\n%s\nwant:\n%s", explicitLeft, left)
+	}
+
+	// "Hi" is 9 columns wide in standard
+	pad := func(n int) string {
+		lines := strings.Split(left, "\n")
+		for i := range lines {
+			lines[i] = strings.Repeat(" ", n) + lines[i]
+		}
+		return strings.Join(lines, "\n")
+	}
+	rtl := 1
+	tests := []struct {
+		name string
+		opts []Option
+		want string
+	}{
+		{name: "center", opts: []Option{WithWidth(40), WithJustify(JustifyCenter)}, want: pad(15)},
+		{name: "right", opts: []Option{WithWidth(40), WithJustify(JustifyRight)}, want: pad(30)},
+		{name: "auto left-to-right", opts: []Option{WithWidth(40), WithJustify(JustifyAuto)}, want: left},
+		{name: "center default width", opts: []Option{WithJustify(JustifyCenter)}, want: pad(35)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Render(text, font, tt.opts...)
+			if err != nil {
+				t.Fatalf("Render() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Render() =\n%s\nwant:\n%s", got, tt.want)
+			}
+
+			var buf bytes.Buffer
+			if err := RenderTo(&buf, text, font, tt.opts...); err != nil {
+				t.Fatalf("RenderTo() error = %v", err)
+			}
+			if buf.String() != got {
+				t.Errorf("RenderTo() =\n%s\nwant:\n%s", buf.String(), got)
+			}
+		})
+	}
+
+	t.Run("auto right-to-left", func(t *testing.T) {
+		got, err := Render(text, font, WithWidth(40), WithPrintDirection(rtl), WithJustify(JustifyAuto))
+		if err != nil {
+			t.Fatalf("Render() error = %v", err)
+		}
+		for _, line := range strings.Split(got, "\n") {
+			if len(line) != 39 {
+				t.Errorf("line %q is %d columns, want 39", line, len(line))
+			}
+		}
+	})
+
+	if _, err := Render(text, font, WithJustify(Justify(7))); !errors.Is(err, ErrInvalidJustify) {
+		t.Errorf("Render() error = %v, want ErrInvalidJustify", err)
+	}
+}
@@ -7,20 +7,35 @@ import (
 )
 
 // Layout represents a bitmask for fitting modes and smushing rules.
-// The layout determines how glyphs are combined when rendering text.
+// The layout determines how glyphs are combined when rendering text, and how
+// consecutive output lines are stacked. Its bits match the FIGfont FullLayout
+// header field.
 //
-// Bits 6-8 control fitting modes (exactly one must be set):
-//   - Bit 6: FitFullWidth - Full width spacing, no overlap
-//   - Bit 7: FitKerning - Minimal spacing, no character overlap
-//   - Bit 8: FitSmushing - Characters can overlap using smushing rules
+// Bits 6-7 control the horizontal fitting mode (at most one may be set):
+//   - No bits: FitFullWidth - Full width spacing, no overlap
+//   - Bit 6: FitKerning - Minimal spacing, no character overlap
+//   - Bit 7: FitSmushing - Characters can overlap using smushing rules
 //
-// Bits 9-14 control smushing rules (only apply when FitSmushing is active):
-//   - Bit 9: RuleEqualChar - Equal characters merge
-//   - Bit 10: RuleUnderscore - Underscores merge with certain characters
-//   - Bit 11: RuleHierarchy - Character hierarchy determines which survives
-//   - Bit 12: RuleOppositePair - Opposite pairs merge (brackets, parens)
-//   - Bit 13: RuleBigX - Diagonal pairs form X patterns
-//   - Bit 14: RuleHardblank - Hardblanks merge into one
+// Bits 0-5 control smushing rules (only apply when FitSmushing is active):
+//   - Bit 0: RuleEqualChar - Equal characters merge
+//   - Bit 1: RuleUnderscore - Underscores merge with certain characters
+//   - Bit 2: RuleHierarchy - Character hierarchy determines which survives
+//   - Bit 3: RuleOppositePair - Opposite pairs merge (brackets, parens)
+//   - Bit 4: RuleBigX - Diagonal pairs form X patterns
+//   - Bit 5: RuleHardblank - Hardblanks merge into one
+//
+// Bits 13-14 control the vertical fitting mode (at most one may be set):
+//   - No bits: FitFullHeight - Output lines stacked at full height
+//   - Bit 13: FitVerticalFitting - Lines moved up until they touch
+//   - Bit 14: FitVerticalSmushing - Lines overlap using vertical rules
+//
+// Bits 8-12 control vertical smushing rules (only apply when
+// FitVerticalSmushing is active):
+//   - Bit 8: RuleVerticalEqualChar - Equal characters merge
+//   - Bit 9: RuleVerticalUnderscore - Underscores merge with certain characters
+//   - Bit 10: RuleVerticalHierarchy - Character hierarchy determines which survives
+//   - Bit 11: RuleHorizontalLine - "-" and "_" merge into "="
+//   - Bit 12: RuleVerticalLine - "|" over "|" merges, and may overlap further
 type Layout uint32
 
 // Fitting mode constants
@@ -33,6 +48,15 @@ const (
 
 	// FitSmushing allows characters to overlap using smushing rules (bit 7)
 	FitSmushing Layout = 1 << 7
+
+	// FitFullHeight stacks output lines at full height (no vertical bits set)
+	FitFullHeight Layout = 0
+
+	// FitVerticalFitting moves each output line up until it touches the one above (bit 13)
+	FitVerticalFitting Layout = 1 << 13
+
+	// FitVerticalSmushing overlaps output lines using vertical smushing rules (bit 14)
+	FitVerticalSmushing Layout = 1 << 14
 )
 
 // Smushing rule constants (bits 0-5)
@@ -54,12 +78,41 @@ const (
 
 	// RuleHardblank merges two hardblanks into one (bit 5)
 	RuleHardblank Layout = 1 << 5
+)
 
-	// AllKnownMask contains all known layout bits for validation
-	// Note: FitFullWidth = 0, so it doesn't contribute to the mask
-	AllKnownMask Layout = FitKerning | FitSmushing |
-		RuleEqualChar | RuleUnderscore | RuleHierarchy |
+// Vertical smushing rule constants (bits 8-12)
+const (
+	// RuleVerticalEqualChar merges equal characters into one (bit 8)
+	RuleVerticalEqualChar Layout = 1 << 8
+
+	// RuleVerticalUnderscore lets characters such as | and / replace an underscore (bit 9)
+	RuleVerticalUnderscore Layout = 1 << 9
+
+	// RuleVerticalHierarchy uses character hierarchy to determine which survives (bit 10)
+	RuleVerticalHierarchy Layout = 1 << 10
+
+	// RuleHorizontalLine merges "-" and "_" into "=" (bit 11)
+	RuleHorizontalLine Layout = 1 << 11
+
+	// RuleVerticalLine merges "|" over "|", letting lines overlap further (bit 12)
+	RuleVerticalLine Layout = 1 << 12
+)
+
+const (
+	// horizontalRuleMask contains the horizontal smushing rule bits
+	horizontalRuleMask = RuleEqualChar | RuleUnderscore | RuleHierarchy |
 		RuleOppositePair | RuleBigX | RuleHardblank
+
+	// verticalRuleMask contains the vertical smushing rule bits
+	verticalRuleMask = RuleVerticalEqualChar | RuleVerticalUnderscore |
+		RuleVerticalHierarchy | RuleHorizontalLine | RuleVerticalLine
+
+	// verticalLayoutMask contains every vertical layout bit
+	verticalLayoutMask = FitVerticalFitting | FitVerticalSmushing | verticalRuleMask
+
+	// AllKnownMask contains all known layout bits for validation
+	// Note: FitFullWidth and FitFullHeight = 0, so they don't contribute to the mask
+	AllKnownMask Layout = FitKerning | FitSmushing | horizontalRuleMask | verticalLayoutMask
 )
 
 // ErrLayoutConflict is returned when multiple fitting modes are set simultaneously
@@ -71,6 +124,9 @@ var ErrInvalidOldLayout = errors.New("invalid OldLayout: must be in range -1..63
 // ErrInvalidFullLayout is returned when FullLayout is outside the valid range (0..32767)
 var ErrInvalidFullLayout = errors.New("invalid FullLayout: must be in range 0..32767")
 
+// ErrInvalidAxisMode is returned when a vertical layout mode is not one of the AxisMode constants
+var ErrInvalidAxisMode = errors.New("invalid axis mode")
+
 // AxisMode represents the fitting mode for horizontal or vertical axis
 type AxisMode int
 
@@ -123,14 +179,15 @@ const (
 )
 
 // NormalizeLayout validates and normalizes a Layout value.
-// It ensures exactly one fitting mode is set:
+// It ensures exactly one fitting mode is set on each axis:
 //   - If both FitKerning and FitSmushing are set, returns ErrLayoutConflict
-//   - If both FitFullWidth and FitKerning are set, returns ErrLayoutConflict
-//   - If both FitFullWidth and FitSmushing are set, returns ErrLayoutConflict
-//   - If no fitting mode is set, defaults to FitFullWidth
+//   - If both FitVerticalFitting and FitVerticalSmushing are set, returns ErrLayoutConflict
+//   - If no horizontal fitting mode is set, defaults to FitFullWidth
+//   - If no vertical fitting mode is set, defaults to FitFullHeight
 //
-// Rule bits are preserved even when FitSmushing is not set, but they are
-// ignored by the renderer unless FitSmushing is the active fitting mode.
+// Rule bits are preserved even when FitSmushing (or FitVerticalSmushing) is
+// not set, but they are ignored by the renderer unless smushing is the active
+// fitting mode on their axis.
 //
 // Note: This function errors on conflicts (for user-provided options), whereas
 // parseFullLayout resolves conflicts by giving smushing precedence (for header
@@ -166,6 +223,12 @@ func NormalizeLayout(layout Layout) (Layout, error) {
 			ErrLayoutConflict, strings.Join(conflictingModes, " + "))
 	}
 
+	// The vertical axis follows the same rule
+	if layout&(FitVerticalFitting|FitVerticalSmushing) == FitVerticalFitting|FitVerticalSmushing {
+		return 0, fmt.Errorf("%w: multiple vertical fitting modes set (FitVerticalFitting + FitVerticalSmushing)",
+			ErrLayoutConflict)
+	}
+
 	// If no bits are set, we have FitFullWidth (since FitFullWidth = 0)
 	// Layout is already valid as-is
 	return layout, nil
@@ -224,15 +287,12 @@ func NormalizeOldLayout(oldLayout int) (Layout, error) {
 	}
 }
 
-// HasRule checks if a specific smushing rule is enabled in the layout.
-// Note that rules only have effect when FitSmushing is the active fitting mode.
+// HasRule checks if a specific smushing rule, horizontal or vertical, is
+// enabled in the layout. Note that rules only have effect when smushing is
+// the active fitting mode on their axis.
 func (l Layout) HasRule(rule Layout) bool {
-	// Only check rule bits (9-14)
-	ruleMask := RuleEqualChar | RuleUnderscore | RuleHierarchy |
-		RuleOppositePair | RuleBigX | RuleHardblank
-
-	// Ensure we're only checking valid rule bits
-	if rule&ruleMask == 0 {
+	// Ensure we're only checking valid rule bits (0-5 and 8-12)
+	if rule&(horizontalRuleMask|verticalRuleMask) == 0 {
 		return false
 	}
 
@@ -251,12 +311,22 @@ func (l Layout) FittingMode() Layout {
 	return fittingBits
 }
 
-// Rules returns only the smushing rule bits from the layout.
+// Rules returns only the horizontal smushing rule bits from the layout.
 // This excludes the fitting mode bits and returns the combination of
-// all active smushing rules.
+// all active horizontal smushing rules.
 func (l Layout) Rules() Layout {
-	return l & (RuleEqualChar | RuleUnderscore | RuleHierarchy |
-		RuleOppositePair | RuleBigX | RuleHardblank)
+	return l & horizontalRuleMask
+}
+
+// VerticalFittingMode returns only the vertical fitting mode bits from the
+// layout: FitFullHeight (0), FitVerticalFitting or FitVerticalSmushing.
+func (l Layout) VerticalFittingMode() Layout {
+	return l & (FitVerticalFitting | FitVerticalSmushing)
+}
+
+// VerticalRules returns only the vertical smushing rule bits from the layout.
+func (l Layout) VerticalRules() Layout {
+	return l & verticalRuleMask
 }
 
 // String returns a human-readable representation of the layout.
@@ -298,6 +368,29 @@ func layoutStringParts(l Layout) []string {
 		parts = append(parts, "RuleHardblank")
 	}
 
+	// Add vertical modes and rules; full height is left implicit
+	if l&FitVerticalFitting != 0 {
+		parts = append(parts, "FitVerticalFitting")
+	}
+	if l&FitVerticalSmushing != 0 {
+		parts = append(parts, "FitVerticalSmushing")
+	}
+	if l&RuleVerticalEqualChar != 0 {
+		parts = append(parts, "RuleVerticalEqualChar")
+	}
+	if l&RuleVerticalUnderscore != 0 {
+		parts = append(parts, "RuleVerticalUnderscore")
+	}
+	if l&RuleVerticalHierarchy != 0 {
+		parts = append(parts, "RuleVerticalHierarchy")
+	}
+	if l&RuleHorizontalLine != 0 {
+		parts = append(parts, "RuleHorizontalLine")
+	}
+	if l&RuleVerticalLine != 0 {
+		parts = append(parts, "RuleVerticalLine")
+	}
+
 	return parts
 }
 
@@ -331,9 +424,9 @@ func (l Layout) String() string {
 		return fmt.Sprintf("0x%08X", uint32(l))
 	}
 
-	// Check for invalid multiple fitting modes
+	// Check for invalid multiple fitting modes on either axis
 	var invalidPrefix string
-	if countFittingModes(l) > 1 {
+	if countFittingModes(l) > 1 || l.VerticalFittingMode() == FitVerticalFitting|FitVerticalSmushing {
 		invalidPrefix = "INVALID:"
 	}
 
@@ -501,11 +594,8 @@ func (m AxisMode) String() string {
 	}
 }
 
-// ToLayout converts NormalizedLayout to the simplified horizontal Layout bitmask
-// used by the rendering engine. This only considers horizontal layout settings.
-//
-// Note: Vertical mode and rules are currently ignored by the renderer. They are
-// parsed and stored for future compatibility but not yet used during rendering.
+// ToLayout converts NormalizedLayout to the Layout bitmask, covering both the
+// horizontal and the vertical mode and rules.
 func (nl NormalizedLayout) ToLayout() Layout {
 	var layout Layout
 
@@ -543,5 +633,15 @@ func (nl NormalizedLayout) ToLayout() Layout {
 		layout = FitSmushing
 	}
 
+	// Vertical rule bits 0-4 map to Layout bits 8-12
+	switch nl.VertMode {
+	case ModeFitting:
+		layout |= FitVerticalFitting
+	case ModeSmushingControlled:
+		layout |= FitVerticalSmushing | Layout(nl.VertRules&vertRuleMask)<<vertRuleShift
+	case ModeSmushingUniversal:
+		layout |= FitVerticalSmushing
+	}
+
 	return layout
 }
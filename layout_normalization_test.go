@@ -600,6 +600,17 @@ func TestNormalizeLayout_OptionValidation(t *testing.T) {
 			input:   FitFullWidth | FitKerning | FitSmushing,
 			wantErr: true,
 		},
+		{
+			name:    "Vertical bits preserved",
+			input:   FitKerning | FitVerticalSmushing | RuleVerticalHierarchy,
+			want:    FitKerning | FitVerticalSmushing | RuleVerticalHierarchy,
+			wantErr: false,
+		},
+		{
+			name:    "Conflicting vertical modes error",
+			input:   FitVerticalFitting | FitVerticalSmushing,
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -664,6 +675,31 @@ func TestNormalizedLayoutToLayout(t *testing.T) {
 			},
 			want: FitSmushing | RuleEqualChar | RuleUnderscore | RuleHierarchy | RuleOppositePair | RuleBigX | RuleHardblank,
 		},
+		{
+			name: "Vertical Fitting",
+			input: NormalizedLayout{
+				HorzMode: ModeFitting,
+				VertMode: ModeFitting,
+			},
+			want: FitKerning | FitVerticalFitting,
+		},
+		{
+			name: "Vertical Controlled Smushing with rules",
+			input: NormalizedLayout{
+				HorzMode:  ModeFull,
+				VertMode:  ModeSmushingControlled,
+				VertRules: 0x19, // Rules 1, 4, 5
+			},
+			want: FitVerticalSmushing | RuleVerticalEqualChar | RuleHorizontalLine | RuleVerticalLine,
+		},
+		{
+			name: "Vertical Universal Smushing",
+			input: NormalizedLayout{
+				HorzMode: ModeSmushingUniversal,
+				VertMode: ModeSmushingUniversal,
+			},
+			want: FitSmushing | FitVerticalSmushing,
+		},
 	}
 
 	for _, tt := range tests {
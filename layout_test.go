@@ -21,6 +21,17 @@ func TestLayoutConstants(t *testing.T) {
 		{"RuleOppositePair", RuleOppositePair, 0x00000008}, // Bit 3
 		{"RuleBigX", RuleBigX, 0x00000010},                 // Bit 4
 		{"RuleHardblank", RuleHardblank, 0x00000020},       // Bit 5
+
+		// Vertical bits match FullLayout bits 8-14
+		{"FitFullHeight", FitFullHeight, 0x00000000},                   // No bits set
+		{"RuleVerticalEqualChar", RuleVerticalEqualChar, 0x00000100},   // Bit 8
+		{"RuleVerticalUnderscore", RuleVerticalUnderscore, 0x00000200}, // Bit 9
+		{"RuleVerticalHierarchy", RuleVerticalHierarchy, 0x00000400},   // Bit 10
+		{"RuleHorizontalLine", RuleHorizontalLine, 0x00000800},         // Bit 11
+		{"RuleVerticalLine", RuleVerticalLine, 0x00001000},             // Bit 12
+		{"FitVerticalFitting", FitVerticalFitting, 0x00002000},         // Bit 13
+		{"FitVerticalSmushing", FitVerticalSmushing, 0x00004000},       // Bit 14
+		{"AllKnownMask", AllKnownMask, 0x00007FFF},                     // Bits 0-14
 	}
 
 	for _, tt := range tests {
@@ -81,6 +92,18 @@ func TestLayoutValidation(t *testing.T) {
 			wantErrContains: "",
 			wantFitting:     FitFullWidth,
 		},
+		{
+			name:            "valid vertical smushing with rules",
+			layout:          FitKerning | FitVerticalSmushing | RuleVerticalEqualChar | RuleVerticalLine,
+			wantErrContains: "",
+			wantFitting:     FitKerning,
+		},
+		{
+			name:            "invalid both vertical fitting modes",
+			layout:          FitSmushing | FitVerticalFitting | FitVerticalSmushing,
+			wantErrContains: "multiple vertical fitting modes",
+			wantFitting:     0,
+		},
 	}
 
 	for _, tt := range tests {
@@ -121,6 +144,37 @@ func TestLayoutHasRule(t *testing.T) {
 	if layout.HasRule(RuleUnderscore) {
 		t.Error("HasRule(RuleUnderscore) = true, want false")
 	}
+
+	vertical := FitVerticalSmushing | RuleHorizontalLine
+	if !vertical.HasRule(RuleHorizontalLine) {
+		t.Error("HasRule(RuleHorizontalLine) = false, want true")
+	}
+	if vertical.HasRule(RuleVerticalEqualChar) {
+		t.Error("HasRule(RuleVerticalEqualChar) = true, want false")
+	}
+	if vertical.HasRule(FitVerticalSmushing) {
+		t.Error("HasRule(FitVerticalSmushing) = true, want false for a fitting mode")
+	}
+}
+
+func TestLayoutVerticalAccessors(t *testing.T) {
+	layout := FitSmushing | RuleEqualChar | FitVerticalSmushing | RuleVerticalUnderscore | RuleVerticalLine
+
+	if got := layout.FittingMode(); got != FitSmushing {
+		t.Errorf("FittingMode() = %v, want FitSmushing", got)
+	}
+	if got := layout.Rules(); got != RuleEqualChar {
+		t.Errorf("Rules() = %v, want RuleEqualChar only", got)
+	}
+	if got := layout.VerticalFittingMode(); got != FitVerticalSmushing {
+		t.Errorf("VerticalFittingMode() = 0x%08X, want FitVerticalSmushing", uint32(got))
+	}
+	if got := layout.VerticalRules(); got != RuleVerticalUnderscore|RuleVerticalLine {
+		t.Errorf("VerticalRules() = 0x%08X, want RuleVerticalUnderscore|RuleVerticalLine", uint32(got))
+	}
+	if got := FitKerning.VerticalFittingMode(); got != FitFullHeight {
+		t.Errorf("VerticalFittingMode() = 0x%08X, want FitFullHeight", uint32(got))
+	}
 }
 
 func TestLayoutFittingMode(t *testing.T) {
@@ -174,6 +228,10 @@ func TestLayoutString(t *testing.T) {
 		{"INVALID:FitKerning|FitSmushing", FitKerning | FitSmushing}, // Multiple fitting modes
 		{"FitSmushing|RuleEqualChar|RuleUnderscore|RuleHierarchy|RuleOppositePair|RuleBigX|RuleHardblank",
 			FitSmushing | RuleEqualChar | RuleUnderscore | RuleHierarchy | RuleOppositePair | RuleBigX | RuleHardblank}, // All rules
+		{"FitKerning|FitVerticalFitting", FitKerning | FitVerticalFitting},
+		{"FitSmushing|RuleBigX|FitVerticalSmushing|RuleVerticalEqualChar|RuleHorizontalLine",
+			FitSmushing | RuleBigX | FitVerticalSmushing | RuleVerticalEqualChar | RuleHorizontalLine},
+		{"INVALID:FitFullWidth|FitVerticalFitting|FitVerticalSmushing", FitVerticalFitting | FitVerticalSmushing},
 		{"FitFullWidth", 0},                // Zero value represents FitFullWidth
 		{"0x12345678", Layout(0x12345678)}, // Unknown bits
 		{"0x80000000", Layout(0x80000000)}, // High bit set
@@ -0,0 +1,75 @@
+package figgo
+
+import (
+	"github.com/ryanlewis/figgo/internal/renderer"
+)
+
+// Metrics describes how text renders, without the rendered text.
+type Metrics struct {
+	// LineWidths is the width in columns of each output line (one FIGlet
+	// line of Height rows each, unless lines are stacked vertically closer)
+	LineWidths []int
+	// Width is the widest output line
+	Width int
+	// Height is the number of output rows
+	Height int
+	// WrappedLines counts output lines that end at a break made to fit the
+	// width, rather than at a newline or the end of the text
+	WrappedLines int
+	// Glyphs counts the glyphs placed in the output
+	Glyphs int
+}
+
+// Measure reports the size text would render at with the given options,
+// without producing the output. It runs the same fitting, smushing and line
+// breaking as Render, so the widths match Render's output exactly, but it
+// does not encode rows or write anything, and allocates far less. Use it to
+// choose a font or width before rendering.
+//
+// Text that renders no glyphs has zero metrics, although Render returns
+// blank rows for it. With WithPixelMode, widths and height are of the
+// packed output.
+//
+// Example:
+//
+//	m, err := figgo.Measure(title, font, figgo.WithWidth(80))
+//	if err == nil && m.WrappedLines > 0 {
+//	    // Too wide for one line; try a smaller font
+//	}
+func Measure(text string, f *Font, opts ...Option) (Metrics, error) {
+	if f == nil {
+		return Metrics{}, ErrUnknownFont
+	}
+	options, err := resolveOptions(f, opts)
+	if err != nil {
+		return Metrics{}, err
+	}
+	return measure(text, f, options)
+}
+
+// measure is Measure with resolved options.
+func measure(text string, f *Font, options *options) (Metrics, error) {
+	m, err := renderer.Measure(text, convertToParserFont(f), options.toInternal())
+	if err != nil {
+		return Metrics{}, err
+	}
+
+	metrics := Metrics{
+		LineWidths:   m.LineWidths,
+		Height:       m.Height,
+		WrappedLines: m.WrappedLines,
+		Glyphs:       m.Glyphs,
+	}
+	if options.pixelMode != PixelOff {
+		// Packed cells cover scaleX columns and scaleY rows
+		scaleX, scaleY := options.pixelMode.pixelScale()
+		for i, w := range metrics.LineWidths {
+			metrics.LineWidths[i] = (w + scaleX - 1) / scaleX
+		}
+		metrics.Height = (metrics.Height + scaleY - 1) / scaleY
+	}
+	for _, w := range metrics.LineWidths {
+		metrics.Width = max(metrics.Width, w)
+	}
+	return metrics, nil
+}
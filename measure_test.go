@@ -0,0 +1,143 @@
+package figgo
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestMeasure(t *testing.T) {
+	font, err := LoadFont("fonts/standard.flf")
+	if err != nil {
+		t.Fatalf("LoadFont() error = %v", err)
+	}
+
+	tests := []struct {
+		name        string
+		text        string
+		opts        []Option
+		wantWrapped int
+		wantGlyphs  int
+	}{
+		{name: "single line", text: "Hello", wantGlyphs: 5},
+		{name: "newlines", text: "Hi\nthere", wantGlyphs: 7},
+		// Spaces are glyphs too; the ones at wrapped breaks are dropped
+		{name: "wrapped", text: "Hello brave new world", opts: []Option{WithWidth(40)}, wantWrapped: 3, wantGlyphs: 18},
+		{name: "word broken", text: "Supercalifragilistic", opts: []Option{WithWidth(30)}, wantWrapped: 2, wantGlyphs: 20},
+		{name: "trimmed", text: "Hi there", opts: []Option{WithTrimWhitespace(true)}, wantGlyphs: 8},
+		{name: "centred", text: "Hi", opts: []Option{WithWidth(60), WithJustify(JustifyCenter)}, wantGlyphs: 2},
+		{name: "right to left", text: "Hello", opts: []Option{WithPrintDirection(1)}, wantGlyphs: 5},
+		{name: "kerning", text: "Hello", opts: []Option{WithLayout(FitKerning)}, wantGlyphs: 5},
+		{name: "balanced", text: "Hello big wide world", opts: []Option{WithWidth(80), WithBalancedWrap(true)}, wantWrapped: 1, wantGlyphs: 19},
+		{name: "hyphenated", text: "Supercalifragilistic", opts: []Option{WithWidth(30), WithWrapMode(WrapChar), WithHyphenation(true)}, wantWrapped: 3, wantGlyphs: 23},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := Render(tt.text, font, tt.opts...)
+			if err != nil {
+				t.Fatalf("Render() error = %v", err)
+			}
+			m, err := Measure(tt.text, font, tt.opts...)
+			if err != nil {
+				t.Fatalf("Measure() error = %v", err)
+			}
+
+			// Widths of each FIGlet line as rendered
+			rows := strings.Split(out, "\n")
+			var want []int
+			for i := 0; i < len(rows); i += font.Height {
+				width := 0
+				for _, row := range rows[i:min(i+font.Height, len(rows))] {
+					width = max(width, len([]rune(row)))
+				}
+				want = append(want, width)
+			}
+			if !reflect.DeepEqual(m.LineWidths, want) {
+				t.Errorf("LineWidths = %v, want %v", m.LineWidths, want)
+			}
+			if m.Height != len(rows) {
+				t.Errorf("Height = %d, want %d", m.Height, len(rows))
+			}
+			if m.Width != slicesMax(want) {
+				t.Errorf("Width = %d, want %d", m.Width, slicesMax(want))
+			}
+			if m.WrappedLines != tt.wantWrapped {
+				t.Errorf("WrappedLines = %d, want %d", m.WrappedLines, tt.wantWrapped)
+			}
+			if m.Glyphs != tt.wantGlyphs {
+				t.Errorf("Glyphs = %d, want %d", m.Glyphs, tt.wantGlyphs)
+			}
+		})
+	}
+}
+
+func TestMeasureVertical(t *testing.T) {
+	font, err := LoadFont("fonts/standard.flf")
+	if err != nil {
+		t.Fatalf("LoadFont() error = %v", err)
+	}
+	opt := WithVerticalLayout(ModeSmushingUniversal, 0)
+
+	out, err := Render("Hey\nyou", font, opt)
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	m, err := Measure("Hey\nyou", font, opt)
+	if err != nil {
+		t.Fatalf("Measure() error = %v", err)
+	}
+	if rows := strings.Count(out, "\n") + 1; m.Height != rows {
+		t.Errorf("Height = %d, want %d", m.Height, rows)
+	}
+	if len(m.LineWidths) != 2 {
+		t.Errorf("LineWidths = %v, want 2 lines", m.LineWidths)
+	}
+}
+
+func TestMeasureErrors(t *testing.T) {
+	font, err := LoadFont("fonts/standard.flf")
+	if err != nil {
+		t.Fatalf("LoadFont() error = %v", err)
+	}
+
+	if _, err := Measure("Hi", nil); !errors.Is(err, ErrUnknownFont) {
+		t.Errorf("Measure(nil font) error = %v, want ErrUnknownFont", err)
+	}
+	if _, err := Measure("Hi", font, WithJustify(Justify(9))); !errors.Is(err, ErrInvalidJustify) {
+		t.Errorf("Measure() error = %v, want ErrInvalidJustify", err)
+	}
+	if _, err := Measure("日本", font); err == nil {
+		t.Error("Measure() expected error for runes missing from the font")
+	}
+	if m, err := Measure("", font); err != nil || m.Height != 0 || m.LineWidths != nil {
+		t.Errorf("Measure(\"\") = %+v, %v, want zero metrics", m, err)
+	}
+}
+
+func TestMeasureAllocations(t *testing.T) {
+	font, err := LoadFont("fonts/standard.flf")
+	if err != nil {
+		t.Fatalf("LoadFont() error = %v", err)
+	}
+	const text = "The quick brown fox jumps over the lazy dog"
+
+	render := testing.AllocsPerRun(20, func() {
+		_, _ = Render(text, font, WithWidth(80))
+	})
+	measure := testing.AllocsPerRun(20, func() {
+		_, _ = Measure(text, font, WithWidth(80))
+	})
+	if measure >= render {
+		t.Errorf("Measure() made %.0f allocations, Render() %.0f; want fewer", measure, render)
+	}
+}
+
+func slicesMax(s []int) int {
+	m := 0
+	for _, v := range s {
+		m = max(m, v)
+	}
+	return m
+}
@@ -0,0 +1,163 @@
+package figgo
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// FontInfo holds the credits found in a font's comment block. Every field is
+// extracted on a best-effort basis and is empty when nothing matched; the raw
+// text is always available from Font.Comments.
+type FontInfo struct {
+	// Author is the font's creator, taken from the first "Author: X",
+	// "Designed by X" or "Name by X" comment (e.g. "Glenn Chappell")
+	Author string
+
+	// License is the comment paragraph granting permission to use or modify
+	// the font, or stating its copyright or licence, with its line breaks kept
+	License string
+
+	// Date is the first date in the comments, exactly as written
+	// (e.g. "3/93", "12 Aug 1994" or "1996-11-02")
+	Date string
+}
+
+var (
+	// authorKeyRe matches explicit author lines such as "Author: X" or "Font designed by X"
+	authorKeyRe = regexp.MustCompile(`(?i)^\s*(?:author|designer|(?:font\s+)?(?:created|designed|drawn|made)\s+by)\s*:?\s+(.+)$`)
+
+	// authorByRe matches the customary first line "Name by X", skipping "Modified by X"
+	authorByRe = regexp.MustCompile(`(?i)^\s*\S.*?\bby\s+(.+)$`)
+
+	// dateRe matches numeric (3/93, 12/25/1996, 1996-11-02) and written (12 Aug 1994,
+	// November 1996) dates
+	dateRe = regexp.MustCompile(`(?i)\b(?:\d{4}-\d{2}-\d{2}|\d{1,2}/(?:\d{1,2}/)?\d{2,4}|` +
+		`(?:\d{1,2}\s+)?(?:jan|feb|mar|apr|may|jun|jul|aug|sep|oct|nov|dec)[a-z]*\.?\s+(?:\d{1,2},?\s+)?\d{4})\b`)
+
+	// licenseRe matches the first line of a licence or copyright paragraph
+	licenseRe = regexp.MustCompile(`(?i)licen[cs]e|permission\s+is|copyright|\(c\)|©|public\s+domain|freeware|all\s+rights\s+reserved`)
+
+	// modifiedRe matches credit lines for later modifications, which are not the author
+	modifiedRe = regexp.MustCompile(`(?i)^\s*(?:modified|updated|changed|converted|fixed|edited)\b`)
+)
+
+// Comments returns the comment lines from the font header. The returned slice
+// is a copy.
+func (f *Font) Comments() []string {
+	if f == nil {
+		return nil
+	}
+	return slices.Clone(f.comments)
+}
+
+// Signature returns the font's file signature: "flf2a" for FIGfonts and
+// "tlf2a" for TOIlet fonts. Fonts made with FontBuilder report "flf2a".
+func (f *Font) Signature() string {
+	if f == nil || f.signature == "" {
+		return "flf2a"
+	}
+	return f.signature
+}
+
+// FullLayout returns the FullLayout header value and whether the header
+// contained one. Values outside 0..32767 are returned as written, although
+// layout normalization ignores them.
+func (f *Font) FullLayout() (int, bool) {
+	if f == nil {
+		return 0, false
+	}
+	return f.fullLayout, f.fullLayoutSet
+}
+
+// CodetagCount returns the Codetag_Count header value and whether the header
+// contained one.
+func (f *Font) CodetagCount() (int, bool) {
+	if f == nil {
+		return 0, false
+	}
+	return f.codetagCount, f.codetagCountSet
+}
+
+// NormalizedLayout returns the font's layout for both axes, derived from its
+// OldLayout and FullLayout header values. Unlike Layout, which only carries
+// the horizontal settings used by the renderer, it keeps the vertical mode
+// and rules.
+func (f *Font) NormalizedLayout() NormalizedLayout {
+	if f == nil {
+		return NormalizedLayout{}
+	}
+	nl, err := NormalizeLayoutFromHeader(f.OldLayout, f.fullLayout, f.fullLayoutSet)
+	if err != nil {
+		// OldLayout out of range: fall back to the horizontal layout in use
+		return parseFullLayout(int(f.Layout & AllKnownMask))
+	}
+	return nl
+}
+
+// Info extracts the author, licence and date from the font's comments.
+func (f *Font) Info() FontInfo {
+	if f == nil {
+		return FontInfo{}
+	}
+	return parseFontInfo(f.comments)
+}
+
+// parseFontInfo scans comment lines for credits. The first match for each
+// field wins.
+func parseFontInfo(comments []string) FontInfo {
+	var info FontInfo
+
+	for _, line := range comments {
+		if m := authorKeyRe.FindStringSubmatch(line); m != nil {
+			info.Author = cleanAuthor(m[1])
+			break
+		}
+	}
+	if info.Author == "" {
+		for _, line := range comments {
+			if modifiedRe.MatchString(line) {
+				continue
+			}
+			if m := authorByRe.FindStringSubmatch(line); m != nil {
+				info.Author = cleanAuthor(m[1])
+				break
+			}
+		}
+	}
+
+	for _, line := range comments {
+		if d := dateRe.FindString(line); d != "" {
+			info.Date = d
+			break
+		}
+	}
+
+	for i, line := range comments {
+		if !licenseRe.MatchString(line) {
+			continue
+		}
+		// The licence runs to the end of its paragraph
+		end := i + 1
+		for end < len(comments) && strings.TrimSpace(comments[end]) != "" {
+			end++
+		}
+		info.License = strings.TrimSpace(strings.Join(comments[i:end], "\n"))
+		break
+	}
+
+	return info
+}
+
+// cleanAuthor trims what usually follows a name on a credit line: remarks
+// after " -- ", a date and surrounding punctuation.
+func cleanAuthor(s string) string {
+	if i := strings.Index(s, " -- "); i >= 0 {
+		s = s[:i]
+	}
+	if loc := dateRe.FindStringIndex(s); loc != nil {
+		s = s[:loc[0]]
+	}
+	s = strings.TrimRight(strings.TrimSpace(s), ",;:.-(")
+	return strings.TrimSpace(s)
+}
@@ -0,0 +1,182 @@
+package figgo
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestFontMetadata(t *testing.T) {
+	standard, err := LoadFont("fonts/standard.flf")
+	if err != nil {
+		t.Fatalf("LoadFont() error = %v", err)
+	}
+
+	if got := standard.Signature(); got != "flf2a" {
+		t.Errorf("Signature() = %q, want flf2a", got)
+	}
+	if got, ok := standard.FullLayout(); got != 24463 || !ok {
+		t.Errorf("FullLayout() = %d, %v, want 24463, true", got, ok)
+	}
+	if got, ok := standard.CodetagCount(); got != 229 || !ok {
+		t.Errorf("CodetagCount() = %d, %v, want 229, true", got, ok)
+	}
+
+	comments := standard.Comments()
+	if len(comments) != standard.CommentLines {
+		t.Fatalf("len(Comments()) = %d, want %d", len(comments), standard.CommentLines)
+	}
+	if !strings.HasPrefix(comments[0], "Standard by Glenn Chappell") {
+		t.Errorf("Comments()[0] = %q", comments[0])
+	}
+	comments[0] = "changed"
+	if standard.Comments()[0] == "changed" {
+		t.Error("Comments() returned the font's own slice")
+	}
+
+	// 24463 = horizontal smushing with rules 1-4, vertical smushing
+	// with rules 1-5
+	want := NormalizedLayout{
+		HorzMode:  ModeSmushingControlled,
+		HorzRules: 0x0F,
+		VertMode:  ModeSmushingControlled,
+		VertRules: 0x1F,
+	}
+	if got := standard.NormalizedLayout(); got != want {
+		t.Errorf("NormalizedLayout() = %v, want %v", got, want)
+	}
+
+	tlf, err := ParseFont(strings.NewReader(buildTLFFont()))
+	if err != nil {
+		t.Fatalf("ParseFont() error = %v", err)
+	}
+	if got := tlf.Signature(); got != "tlf2a" {
+		t.Errorf("TOIlet Signature() = %q, want tlf2a", got)
+	}
+
+	// Headers without the optional fields
+	minimal, err := ParseFont(strings.NewReader(minimalFont))
+	if err != nil {
+		t.Fatalf("ParseFont() error = %v", err)
+	}
+	if _, ok := minimal.FullLayout(); ok {
+		t.Error("FullLayout() reported a value for a header without one")
+	}
+	if _, ok := minimal.CodetagCount(); ok {
+		t.Error("CodetagCount() reported a value for a header without one")
+	}
+	if got := minimal.NormalizedLayout(); got != (NormalizedLayout{HorzMode: ModeFitting}) {
+		t.Errorf("NormalizedLayout() = %v, want horizontal fitting only", got)
+	}
+
+	built, err := NewFontBuilder(1, 1, '$').SetLayout(FitKerning).Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+	if got := built.Signature(); got != "flf2a" {
+		t.Errorf("built Signature() = %q, want flf2a", got)
+	}
+	if got := built.NormalizedLayout(); got != (NormalizedLayout{HorzMode: ModeFitting}) {
+		t.Errorf("built NormalizedLayout() = %v, want horizontal fitting only", got)
+	}
+}
+
+func TestFontInfo(t *testing.T) {
+	tests := []struct {
+		name     string
+		comments []string
+		want     FontInfo
+	}{
+		{
+			name: "figlet style credits",
+			comments: []string{
+				"Standard by Glenn Chappell & Ian Chai 3/93 -- based on Frank's .sig",
+				"Includes ISO Latin-1",
+				"figlet release 2.1 -- 12 Aug 1994",
+				"Permission is hereby given to modify this font, as long as the",
+				"modifier's name is placed on a comment line.",
+				"",
+				"Modified by Paul Burton <solution@earthlink.net> 12/96 to include new parameter",
+			},
+			want: FontInfo{
+				Author: "Glenn Chappell & Ian Chai",
+				License: "Permission is hereby given to modify this font, as long as the\n" +
+					"modifier's name is placed on a comment line.",
+				Date: "3/93",
+			},
+		},
+		{
+			name: "explicit fields",
+			comments: []string{
+				"Font: Blocky",
+				"Author: Jane Doe <jane@example.com>",
+				"Date: 2021-06-30",
+				"License: MIT",
+			},
+			want: FontInfo{
+				Author:  "Jane Doe <jane@example.com>",
+				License: "License: MIT",
+				Date:    "2021-06-30",
+			},
+		},
+		{
+			name: "modification credits are not the author",
+			comments: []string{
+				"Modified by Someone Else, November 1996",
+				"Copyright (c) 1996 Original Author",
+			},
+			want: FontInfo{
+				License: "Copyright (c) 1996 Original Author",
+				Date:    "November 1996",
+			},
+		},
+		{
+			name:     "no credits",
+			comments: []string{"Just a font", "with no metadata"},
+			want:     FontInfo{},
+		},
+		{
+			name: "no comments",
+			want: FontInfo{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseFontInfo(tt.comments); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseFontInfo() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFontInfoBundledFonts(t *testing.T) {
+	for _, name := range []string{"standard", "slant", "small", "big"} {
+		t.Run(name, func(t *testing.T) {
+			font, err := LoadFont("fonts/" + name + ".flf")
+			if err != nil {
+				t.Fatalf("LoadFont() error = %v", err)
+			}
+			info := font.Info()
+			if !strings.HasPrefix(info.Author, "Glenn Chappell") {
+				t.Errorf("Author = %q, want Glenn Chappell", info.Author)
+			}
+			if !strings.HasPrefix(info.License, "Permission is hereby given") {
+				t.Errorf("License = %q", info.License)
+			}
+			if info.Date == "" {
+				t.Error("Date is empty")
+			}
+		})
+	}
+}
+
+func TestFontMetadataNil(t *testing.T) {
+	var f *Font
+	if f.Comments() != nil || f.Info() != (FontInfo{}) || f.NormalizedLayout() != (NormalizedLayout{}) {
+		t.Error("nil Font accessors should return zero values")
+	}
+	if _, ok := f.FullLayout(); ok {
+		t.Error("nil Font FullLayout() reported a value")
+	}
+}
@@ -0,0 +1,44 @@
+package figgo
+
+import "testing"
+
+func TestWithParagraphMode(t *testing.T) {
+	font, err := LoadFont("fonts/standard.flf")
+	if err != nil {
+		t.Fatalf("LoadFont() error = %v", err)
+	}
+
+	tests := []struct {
+		name string
+		text string
+		opts []Option
+		same string
+	}{
+		{name: "lines are joined", text: "log\nline", opts: []Option{WithParagraphMode(true)}, same: "log line"},
+		{name: "off by default", text: "log\nline", same: "log\nline"},
+		{name: "disabled", text: "log\nline", opts: []Option{WithParagraphMode(false)}, same: "log\nline"},
+		{name: "blank line is a paragraph break", text: "one\ntwo\n\nthree", opts: []Option{WithParagraphMode(true)}, same: "one two\nthree"},
+		{
+			name: "paragraph wraps at the width",
+			text: "the quick\nbrown fox",
+			opts: []Option{WithParagraphMode(true)},
+			same: "the quick brown fox",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want, err := Render(tt.same, font, WithWidth(60))
+			if err != nil {
+				t.Fatalf("Render() error = %v", err)
+			}
+			got, err := Render(tt.text, font, append([]Option{WithWidth(60)}, tt.opts...)...)
+			if err != nil {
+				t.Fatalf("Render() error = %v", err)
+			}
+			if got != want {
+				t.Errorf("Render() =\n%s\nwant:\n%s", got, want)
+			}
+		})
+	}
+}
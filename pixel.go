@@ -0,0 +1,145 @@
+package figgo
+
+import (
+	"github.com/ryanlewis/figgo/internal/renderer"
+)
+
+// PixelMode is how output is packed into pixel characters.
+type PixelMode int
+
+// Pixel modes
+const (
+	// PixelOff writes characters as rendered (the default)
+	PixelOff PixelMode = iota
+	// PixelHalfBlock packs each column of two rows into a half block
+	// (▀, ▄ or █), halving the height
+	PixelHalfBlock
+	// PixelBraille packs each block of two columns by four rows into a
+	// Braille pattern, halving the width and quartering the height
+	PixelBraille
+)
+
+// halfBlocks maps the top and bottom pixels of a cell (bit 0 and bit 1) to
+// the character drawing them.
+var halfBlocks = [4]rune{' ', '▀', '▄', '█'}
+
+// brailleDots is the bit of the Braille pattern for each pixel of a cell,
+// by row and then column.
+var brailleDots = [4][2]rune{{0x01, 0x08}, {0x02, 0x10}, {0x04, 0x20}, {0x40, 0x80}}
+
+// brailleBlank is the first Braille pattern, with no dots raised.
+const brailleBlank = 0x2800
+
+// WithPixelMode treats each rendered character as a pixel, on unless it is
+// a space, and packs the pixels into Unicode half blocks or Braille patterns
+// for a smaller footprint. Packing happens after smushing, and the width
+// given to WithWidth is the packed width, so lines wrap to fit it.
+// Trailing blank cells are trimmed with WithTrimWhitespace.
+//
+// Style functions see the packed cells, each with the source of its first
+// lit pixel. RenderGrid, RenderHTML, RenderSVG and Measure all work on the
+// packed output; RenderImage ignores the mode, as it draws pixels already.
+func WithPixelMode(mode PixelMode) Option {
+	return func(opts *options) {
+		opts.pixelMode = mode
+	}
+}
+
+// pixelScale returns how many columns and rows of rendered characters make
+// up one packed cell.
+func (m PixelMode) pixelScale() (cols, rows int) {
+	switch m {
+	case PixelHalfBlock:
+		return 1, 2
+	case PixelBraille:
+		return 2, 4
+	default:
+		return 1, 1
+	}
+}
+
+// renderCells renders text as rows of cells, packed into pixels when the
+// options ask for it. Every output format but plain text is written from
+// these cells.
+func renderCells(text string, f *Font, options *options) ([][]renderer.Cell, error) {
+	rows, err := renderer.RenderGrid(text, convertToParserFont(f), options.toInternal())
+	if err != nil {
+		return nil, err
+	}
+	if options.pixelMode != PixelOff {
+		rows = packPixels(rows, options.pixelMode, options.trimWhitespace)
+	}
+	return rows, nil
+}
+
+// packPixels packs rows of cells into pixel characters. Each packed cell
+// keeps the first lit cell it covers, or the first cell when none is lit.
+func packPixels(rows [][]renderer.Cell, mode PixelMode, trim bool) [][]renderer.Cell {
+	scaleX, scaleY := mode.pixelScale()
+	packed := make([][]renderer.Cell, 0, (len(rows)+scaleY-1)/scaleY)
+
+	for top := 0; top < len(rows); top += scaleY {
+		block := rows[top:min(top+scaleY, len(rows))]
+		width := 0
+		for _, row := range block {
+			width = max(width, (len(row)+scaleX-1)/scaleX)
+		}
+
+		out := make([]renderer.Cell, width)
+		for x := range out {
+			cell := renderer.Cell{Rune: ' ', Source: -1}
+			var bits rune
+			found, lit := false, false
+			for dy, row := range block {
+				for dx := 0; dx < scaleX; dx++ {
+					col := x*scaleX + dx
+					if col >= len(row) {
+						continue
+					}
+					c := row[col]
+					on := c.Rune != ' '
+					if !found || on && !lit {
+						cell, found, lit = c, true, on
+					}
+					if on {
+						bits |= pixelBit(mode, dx, dy)
+					}
+				}
+			}
+			cell.Rune = pixelRune(mode, bits)
+			cell.Hardblank = false
+			out[x] = cell
+		}
+
+		if trim {
+			n := len(out)
+			for n > 0 && out[n-1].Rune == ' ' {
+				n--
+			}
+			out = out[:n]
+		}
+		packed = append(packed, out)
+	}
+	return packed
+}
+
+// pixelBit returns the bit for the pixel at column dx and row dy of a cell.
+func pixelBit(mode PixelMode, dx, dy int) rune {
+	if mode == PixelBraille {
+		return brailleDots[dy][dx]
+	}
+	return 1 << dy
+}
+
+// pixelRune returns the character drawing the given pixels; cells with no
+// pixels lit are spaces.
+func pixelRune(mode PixelMode, bits rune) rune {
+	switch {
+	case bits == 0:
+		return ' '
+	case mode == PixelBraille:
+		return brailleBlank + bits
+	default:
+		return halfBlocks[bits]
+	}
+}
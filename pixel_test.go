@@ -0,0 +1,174 @@
+package figgo
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/ryanlewis/figgo/internal/renderer"
+)
+
+// cellRows builds cell rows from lines of text.
+func cellRows(lines ...string) [][]renderer.Cell {
+	rows := make([][]renderer.Cell, len(lines))
+	for i, line := range lines {
+		for j, r := range []rune(line) {
+			rows[i] = append(rows[i], renderer.Cell{Rune: r, Source: j})
+		}
+	}
+	return rows
+}
+
+// cellText joins the runes of cell rows with newlines.
+func cellText(rows [][]renderer.Cell) string {
+	lines := make([]string, len(rows))
+	for i, row := range rows {
+		for _, c := range row {
+			lines[i] += string(c.Rune)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+func TestPackPixels(t *testing.T) {
+	tests := []struct {
+		name  string
+		lines []string
+		mode  PixelMode
+		trim  bool
+		want  string
+	}{
+		{name: "half blocks", lines: []string{"## #", "# ##"}, mode: PixelHalfBlock, want: "█▀▄█"},
+		{name: "odd height", lines: []string{"# ", "##", " #"}, mode: PixelHalfBlock, want: "█▄\n ▀"},
+		{name: "ragged rows", lines: []string{"#", "   #"}, mode: PixelHalfBlock, want: "▀  ▄"},
+		{name: "hardblank is off", lines: []string{"$#"}, mode: PixelHalfBlock, want: " ▀"},
+		{name: "trimmed", lines: []string{"#  ", "   "}, mode: PixelHalfBlock, trim: true, want: "▀"},
+		{name: "untrimmed", lines: []string{"#  ", "   "}, mode: PixelHalfBlock, want: "▀  "},
+		{name: "braille dots", lines: []string{"# ", " #", "# ", " #"}, mode: PixelBraille, want: "⢕"},
+		{name: "braille full", lines: []string{"##", "##", "##", "##"}, mode: PixelBraille, want: "⣿"},
+		{name: "braille odd width", lines: []string{"###"}, mode: PixelBraille, want: "⠉⠁"},
+		{name: "braille blank", lines: []string{"  ", "  "}, mode: PixelBraille, want: " "},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rows := cellRows(tt.lines...)
+			for _, row := range rows {
+				for j := range row {
+					if row[j].Rune == '$' {
+						row[j] = renderer.Cell{Rune: ' ', Source: j, Hardblank: true}
+					}
+				}
+			}
+			if got := cellText(packPixels(rows, tt.mode, tt.trim)); got != tt.want {
+				t.Errorf("packPixels() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	// A packed cell keeps the first lit cell it covers
+	packed := packPixels(cellRows(" #", "##"), PixelHalfBlock, false)
+	if c := packed[0][0]; c.Source != 0 || c.Rune != '▄' {
+		t.Errorf("packed cell = %+v, want the lower cell's source 0", c)
+	}
+	if c := packed[0][1]; c.Source != 1 {
+		t.Errorf("packed cell = %+v, want source 1", c)
+	}
+}
+
+func TestRenderPixels(t *testing.T) {
+	font, err := LoadFont("fonts/big.flf")
+	if err != nil {
+		t.Fatalf("LoadFont() error = %v", err)
+	}
+
+	for _, mode := range []PixelMode{PixelHalfBlock, PixelBraille} {
+		scaleX, scaleY := mode.pixelScale()
+		for _, text := range []string{"Hello", "Hello World, how are you?", "a\nb", ""} {
+			opts := []Option{WithWidth(30), WithPixelMode(mode)}
+			got, err := Render(text, font, opts...)
+			if err != nil {
+				t.Fatalf("Render() error = %v", err)
+			}
+
+			// Each packed cell lights the pixels of the characters it covers,
+			// laid out as unpacked at the matching width
+			plain, err := Render(text, font, WithWidth(30*scaleX-(scaleX-1)))
+			if err != nil {
+				t.Fatalf("Render() error = %v", err)
+			}
+			plainRows := strings.Split(plain, "\n")
+			gotRows := strings.Split(got, "\n")
+			if want := (len(plainRows) + scaleY - 1) / scaleY; len(gotRows) != want {
+				t.Fatalf("mode %d, %q: %d rows, want %d", mode, text, len(gotRows), want)
+			}
+			for i, row := range gotRows {
+				if n := utf8.RuneCountInString(row); n > 29 {
+					t.Errorf("mode %d, %q: row %q is %d wide, want at most 29", mode, text, row, n)
+				}
+				for j, r := range []rune(row) {
+					for dy := 0; dy < scaleY; dy++ {
+						for dx := 0; dx < scaleX; dx++ {
+							on := false
+							if y := i*scaleY + dy; y < len(plainRows) {
+								if line := []rune(plainRows[y]); j*scaleX+dx < len(line) {
+									on = line[j*scaleX+dx] != ' '
+								}
+							}
+							lit := r != ' ' && (mode == PixelBraille && (r-brailleBlank)&pixelBit(mode, dx, dy) != 0 ||
+								mode == PixelHalfBlock && (r == '█' || r == halfBlocks[1<<dy]))
+							if on != lit {
+								t.Fatalf("mode %d, %q: cell (%d, %d) %q pixel (%d, %d) = %v, want %v", mode, text, i, j, r, dx, dy, lit, on)
+							}
+						}
+					}
+				}
+			}
+
+			// The grid, the writer and the metrics agree with Render
+			grid, err := RenderGrid(text, font, opts...)
+			if err != nil || grid.String() != got {
+				t.Errorf("mode %d, %q: RenderGrid() = %q, %v, want %q", mode, text, grid.String(), err, got)
+			}
+			var sb strings.Builder
+			if err := RenderTo(&sb, text, font, opts...); err != nil || sb.String() != got {
+				t.Errorf("mode %d, %q: RenderTo() = %q, %v, want %q", mode, text, sb.String(), err, got)
+			}
+			m, err := Measure(text, font, opts...)
+			if err != nil {
+				t.Fatalf("Measure() error = %v", err)
+			}
+			width := 0
+			for _, row := range gotRows {
+				width = max(width, utf8.RuneCountInString(row))
+			}
+			if text != "" && (m.Width != width || m.Height != len(gotRows)) {
+				t.Errorf("mode %d, %q: Measure() = %dx%d, want %dx%d", mode, text, m.Width, m.Height, width, len(gotRows))
+			}
+		}
+	}
+}
+
+func TestRenderPixelsTrimmed(t *testing.T) {
+	font, err := LoadFont("fonts/standard.flf")
+	if err != nil {
+		t.Fatalf("LoadFont() error = %v", err)
+	}
+	got, err := Render("Hi there", font, WithPixelMode(PixelBraille), WithTrimWhitespace(true), WithRainbow())
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	for _, line := range strings.Split(sgrPattern.ReplaceAllString(got, ""), "\n") {
+		if strings.HasSuffix(line, " ") {
+			t.Errorf("line %q has trailing spaces", line)
+		}
+	}
+	if !strings.Contains(got, "\x1b[") {
+		t.Error("packed output is not styled")
+	}
+
+	if _, err := Render("Hi", font, WithPixelMode(PixelMode(5))); !errors.Is(err, ErrInvalidPixelMode) {
+		t.Errorf("Render() error = %v, want %v", err, ErrInvalidPixelMode)
+	}
+}
@@ -0,0 +1,414 @@
+package figgo
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/ryanlewis/figgo/internal/renderer"
+)
+
+// Color is a 24-bit RGB colour. The zero Color is the terminal's default.
+type Color uint32
+
+// colorSet marks a Color as set, so that black differs from the default
+const colorSet Color = 1 << 24
+
+// RGB returns the colour with the given red, green and blue components.
+func RGB(r, g, b uint8) Color {
+	return colorSet | Color(r)<<16 | Color(g)<<8 | Color(b)
+}
+
+// RGB returns the colour's components, and false for the default colour.
+func (c Color) RGB() (r, g, b uint8, ok bool) {
+	return uint8(c >> 16), uint8(c >> 8), uint8(c), c&colorSet != 0
+}
+
+// String returns the colour as "#rrggbb", or "default".
+func (c Color) String() string {
+	r, g, b, ok := c.RGB()
+	if !ok {
+		return "default"
+	}
+	return fmt.Sprintf("#%02x%02x%02x", r, g, b)
+}
+
+// ansiPalette is the xterm palette of the 16 standard ANSI colours, in SGR
+// order: black, red, green, yellow, blue, magenta, cyan, white, then the
+// bright variants.
+var ansiPalette = [16]Color{
+	RGB(0, 0, 0), RGB(205, 0, 0), RGB(0, 205, 0), RGB(205, 205, 0),
+	RGB(0, 0, 238), RGB(205, 0, 205), RGB(0, 205, 205), RGB(229, 229, 229),
+	RGB(127, 127, 127), RGB(255, 0, 0), RGB(0, 255, 0), RGB(255, 255, 0),
+	RGB(92, 92, 255), RGB(255, 0, 255), RGB(0, 255, 255), RGB(255, 255, 255),
+}
+
+// colorNames are the names ParseColor accepts, in ansiPalette order.
+var colorNames = [16]string{
+	"black", "red", "green", "yellow", "blue", "magenta", "cyan", "white",
+	"bright-black", "bright-red", "bright-green", "bright-yellow",
+	"bright-blue", "bright-magenta", "bright-cyan", "bright-white",
+}
+
+// ParseColor parses a colour given as "#rrggbb", "#rgb" or the name of one
+// of the 16 ANSI colours ("red", "bright-blue", ...), which map to the xterm
+// palette. Names are case-insensitive.
+func ParseColor(s string) (Color, error) {
+	name := strings.ToLower(strings.TrimSpace(s))
+	for i, n := range colorNames {
+		if name == n {
+			return ansiPalette[i], nil
+		}
+	}
+
+	hex, ok := strings.CutPrefix(name, "#")
+	if ok && len(hex) == 3 {
+		hex = string([]byte{hex[0], hex[0], hex[1], hex[1], hex[2], hex[2]})
+	}
+	if !ok || len(hex) != 6 {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidColor, s)
+	}
+	v, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidColor, s)
+	}
+	return RGB(uint8(v>>16), uint8(v>>8), uint8(v)), nil
+}
+
+// Style is how a cell of output is drawn. The zero Style is plain text.
+type Style struct {
+	Fg        Color // Foreground colour
+	Bg        Color // Background colour
+	Bold      bool
+	Italic    bool
+	Underline bool
+}
+
+// over returns s drawn over base: colours s sets replace base's, and
+// attributes add to it.
+func (s Style) over(base Style) Style {
+	if s.Fg != 0 {
+		base.Fg = s.Fg
+	}
+	if s.Bg != 0 {
+		base.Bg = s.Bg
+	}
+	base.Bold = base.Bold || s.Bold
+	base.Italic = base.Italic || s.Italic
+	base.Underline = base.Underline || s.Underline
+	return base
+}
+
+// visibleOnSpace reports whether the style shows on a space.
+func (s Style) visibleOnSpace() bool {
+	return s.Bg != 0 || s.Underline
+}
+
+// CellInfo describes a cell of output to a style function.
+type CellInfo struct {
+	Cell
+	// Row and Col are the cell's position in the output
+	Row, Col int
+	// Width and Height are the size of the output: the longest row and the
+	// number of rows
+	Width, Height int
+}
+
+// ColorMode is the kind of ANSI escape sequences styled output is written
+// with.
+type ColorMode int
+
+// Color modes
+const (
+	// ColorNone writes plain text, ignoring styles (e.g. for NO_COLOR)
+	ColorNone ColorMode = iota
+	// Color16 writes the 16 standard colours, nearest match
+	Color16
+	// Color256 writes the xterm 256-colour palette, nearest match
+	Color256
+	// ColorTrue writes 24-bit colour
+	ColorTrue
+)
+
+// GradientDirection is the axis a gradient runs along.
+type GradientDirection int
+
+// Gradient directions
+const (
+	// GradientHorizontal runs from the first column to the last
+	GradientHorizontal GradientDirection = iota
+	// GradientVertical runs from the first row to the last
+	GradientVertical
+)
+
+// rainbowColors are the colours WithRainbow cycles through.
+var rainbowColors = []Color{
+	RGB(255, 0, 0), RGB(255, 127, 0), RGB(255, 255, 0), RGB(0, 255, 0),
+	RGB(0, 127, 255), RGB(75, 0, 130), RGB(148, 0, 211),
+}
+
+// WithStyle styles each cell of the output with fn, which is called after
+// layout and smushing, so a column merged from two characters is drawn in
+// one style. Styled output is written with ANSI escape sequences (see
+// WithColorMode).
+//
+// Style options layer in order: a later one's colours replace an earlier
+// one's, and its attributes add to them. Spaces only take the parts of a
+// style that show on them (background and underline), so plain gaps and
+// trimmed lines do not carry escape sequences.
+//
+// Example:
+//
+//	// Bold, with the first word's characters in red
+//	figgo.Render("Hello World", font, figgo.WithStyle(func(c figgo.CellInfo) figgo.Style {
+//	    s := figgo.Style{Bold: true}
+//	    if c.Source >= 0 && c.Source < 5 {
+//	        s.Fg = figgo.RGB(255, 0, 0)
+//	    }
+//	    return s
+//	}))
+func WithStyle(fn func(cell CellInfo) Style) Option {
+	return func(opts *options) {
+		if fn != nil {
+			opts.stylers = append(opts.stylers, fn)
+		}
+	}
+}
+
+// WithGlyphStyles styles each input character's glyph with the next of
+// styles, cycling through them: input rune i gets styles[i%len(styles)].
+func WithGlyphStyles(styles ...Style) Option {
+	styles = append([]Style(nil), styles...)
+	return WithStyle(func(c CellInfo) Style {
+		if len(styles) == 0 || c.Source < 0 {
+			return Style{}
+		}
+		return styles[c.Source%len(styles)]
+	})
+}
+
+// WithGradient colours the output's foreground with a linear gradient from
+// one colour to another, across the columns or down the rows.
+func WithGradient(from, to Color, dir GradientDirection) Option {
+	return WithStyle(func(c CellInfo) Style {
+		pos, span := c.Col, c.Width
+		if dir == GradientVertical {
+			pos, span = c.Row, c.Height
+		}
+		t := 0.0
+		if span > 1 {
+			t = float64(pos) / float64(span-1)
+		}
+		return Style{Fg: blendColors(from, to, t)}
+	})
+}
+
+// WithRainbow colours each input character's glyph with the next colour of
+// the rainbow.
+func WithRainbow() Option {
+	styles := make([]Style, len(rainbowColors))
+	for i, c := range rainbowColors {
+		styles[i] = Style{Fg: c}
+	}
+	return WithGlyphStyles(styles...)
+}
+
+// WithColorMode sets the escape sequences styled output is written with.
+// The default is Color256, which nearly every terminal supports; ColorNone
+// turns styling off.
+func WithColorMode(mode ColorMode) Option {
+	return func(opts *options) {
+		opts.colorMode = &mode
+	}
+}
+
+// blendColors returns the colour a fraction t of the way from a to b.
+func blendColors(a, b Color, t float64) Color {
+	ar, ag, ab, _ := a.RGB()
+	br, bg, bb, _ := b.RGB()
+	mix := func(x, y uint8) uint8 {
+		return uint8(float64(x) + (float64(y)-float64(x))*t + 0.5)
+	}
+	return RGB(mix(ar, br), mix(ag, bg), mix(ab, bb))
+}
+
+// styled reports whether output is styled.
+func (o *options) styled() bool {
+	return len(o.stylers) > 0 && (o.colorMode == nil || *o.colorMode != ColorNone)
+}
+
+// writeCells renders text through the cell grid and writes it as text, with
+// the options' styles as ANSI escape sequences when output is styled.
+func writeCells(w io.Writer, text string, f *Font, options *options) error {
+	rows, err := renderCells(text, f, options)
+	if err != nil {
+		return err
+	}
+	mode := Color256
+	if options.colorMode != nil {
+		mode = *options.colorMode
+	}
+
+	styled := options.styled()
+	width := gridWidth(rows)
+	const reset = "\x1b[0m"
+	var buf, sgr []byte
+	for i, row := range rows {
+		var cur Style
+		curSGR := reset // styles that map to the same colours share a sequence
+		for j, c := range row {
+			var s Style
+			if styled {
+				s = options.cellStyle(CellInfo{Cell: Cell(c), Row: i, Col: j, Width: width, Height: len(rows)}, cur)
+			}
+			if s != cur {
+				sgr = appendSGR(sgr[:0], s, mode)
+				if string(sgr) != curSGR {
+					buf = append(buf, sgr...)
+					curSGR = string(sgr)
+				}
+				cur = s
+			}
+			buf = utf8.AppendRune(buf, c.Rune)
+		}
+		if curSGR != reset {
+			buf = append(buf, reset...)
+		}
+		if i < len(rows)-1 {
+			buf = append(buf, '\n')
+		}
+		if len(buf) > 4096 {
+			if _, err := w.Write(buf); err != nil {
+				return err
+			}
+			buf = buf[:0]
+		}
+	}
+	_, err = w.Write(buf)
+	return err
+}
+
+// cellStyle returns the style of a cell from the options' style functions,
+// given the style of the cell before it. A space keeps that style when
+// neither would show on it, so gaps do not switch styles.
+func (o *options) cellStyle(info CellInfo, prev Style) Style {
+	var s Style
+	for _, fn := range o.stylers {
+		s = fn(info).over(s)
+	}
+	if info.Rune == ' ' && !s.visibleOnSpace() && !prev.visibleOnSpace() {
+		return prev
+	}
+	return s
+}
+
+// gridWidth returns the length of the longest row.
+func gridWidth(rows [][]renderer.Cell) int {
+	width := 0
+	for _, row := range rows {
+		width = max(width, len(row))
+	}
+	return width
+}
+
+// appendSGR appends the escape sequence that switches to style s, resetting
+// whatever was set before.
+func appendSGR(buf []byte, s Style, mode ColorMode) []byte {
+	buf = append(buf, "\x1b[0"...)
+	if s.Bold {
+		buf = append(buf, ";1"...)
+	}
+	if s.Italic {
+		buf = append(buf, ";3"...)
+	}
+	if s.Underline {
+		buf = append(buf, ";4"...)
+	}
+	buf = appendColorSGR(buf, s.Fg, mode, false)
+	buf = appendColorSGR(buf, s.Bg, mode, true)
+	return append(buf, 'm')
+}
+
+// appendColorSGR appends the SGR parameters for a foreground or background
+// colour in the given mode.
+func appendColorSGR(buf []byte, c Color, mode ColorMode, bg bool) []byte {
+	r, g, b, ok := c.RGB()
+	if !ok {
+		return buf
+	}
+	switch mode {
+	case ColorTrue:
+		prefix := ";38;2;"
+		if bg {
+			prefix = ";48;2;"
+		}
+		return fmt.Appendf(buf, "%s%d;%d;%d", prefix, r, g, b)
+	case Color256:
+		prefix := ";38;5;"
+		if bg {
+			prefix = ";48;5;"
+		}
+		return fmt.Appendf(buf, "%s%d", prefix, color256(r, g, b))
+	default:
+		n := color16(c)
+		code := 30 + n
+		if n >= 8 {
+			code = 90 + n - 8
+		}
+		if bg {
+			code += 10
+		}
+		return fmt.Appendf(buf, ";%d", code)
+	}
+}
+
+// color16 returns the index of the nearest of the 16 ANSI colours to c.
+func color16(c Color) int {
+	best, bestDist := 0, -1
+	for i, p := range ansiPalette {
+		if d := colorDistance(c, p); bestDist < 0 || d < bestDist {
+			best, bestDist = i, d
+		}
+	}
+	return best
+}
+
+// color256 returns the nearest xterm 256-colour palette index to an RGB
+// colour, choosing between the 6x6x6 cube and the grey ramp.
+func color256(r, g, b uint8) int {
+	levels := [6]int{0, 95, 135, 175, 215, 255}
+	nearestLevel := func(v uint8) int {
+		if v < 48 {
+			return 0
+		}
+		if v < 115 {
+			return 1
+		}
+		return (int(v) - 35) / 40
+	}
+	ri, gi, bi := nearestLevel(r), nearestLevel(g), nearestLevel(b)
+	cube := 16 + 36*ri + 6*gi + bi
+	cubeColor := RGB(uint8(levels[ri]), uint8(levels[gi]), uint8(levels[bi]))
+
+	// Grey ramp 232-255 runs from 8 to 238 in steps of 10
+	avg := (int(r) + int(g) + int(b)) / 3
+	grey := min(max((avg-3)/10, 0), 23)
+	greyLevel := uint8(8 + 10*grey)
+	greyColor := RGB(greyLevel, greyLevel, greyLevel)
+
+	c := RGB(r, g, b)
+	if colorDistance(c, greyColor) < colorDistance(c, cubeColor) {
+		return 232 + grey
+	}
+	return cube
+}
+
+// colorDistance returns the squared distance between two colours.
+func colorDistance(a, b Color) int {
+	ar, ag, ab, _ := a.RGB()
+	br, bg, bb, _ := b.RGB()
+	dr, dg, db := int(ar)-int(br), int(ag)-int(bg), int(ab)-int(bb)
+	return dr*dr + dg*dg + db*db
+}
@@ -0,0 +1,222 @@
+package figgo
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+// sgrPattern matches the escape sequences styled output is written with.
+var sgrPattern = regexp.MustCompile("\x1b\\[[0-9;]*m")
+
+// danglingPattern matches an escape sequence with no text after it.
+var danglingPattern = regexp.MustCompile("\x1b\\[[0-9;]*m(\x1b|$)")
+
+func TestParseColor(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    Color
+		wantErr bool
+	}{
+		{in: "red", want: RGB(205, 0, 0)},
+		{in: "Bright-Blue", want: RGB(92, 92, 255)},
+		{in: "black", want: RGB(0, 0, 0)},
+		{in: "#ff8000", want: RGB(255, 128, 0)},
+		{in: " #FFF ", want: RGB(255, 255, 255)},
+		{in: "mauve", wantErr: true},
+		{in: "#12345", wantErr: true},
+		{in: "#gggggg", wantErr: true},
+		{in: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := ParseColor(tt.in)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidColor) {
+					t.Errorf("ParseColor() error = %v, want %v", err, ErrInvalidColor)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Errorf("ParseColor() = %v, %v, want %v", got, err, tt.want)
+			}
+		})
+	}
+
+	// Black is a colour, not the default
+	if _, _, _, ok := RGB(0, 0, 0).RGB(); !ok {
+		t.Error("RGB(0, 0, 0) is the default colour")
+	}
+}
+
+func TestAppendSGR(t *testing.T) {
+	orange := RGB(255, 128, 0)
+
+	tests := []struct {
+		name  string
+		style Style
+		mode  ColorMode
+		want  string
+	}{
+		{name: "plain", want: "\x1b[0m"},
+		{name: "attributes", style: Style{Bold: true, Italic: true, Underline: true}, mode: Color256, want: "\x1b[0;1;3;4m"},
+		{name: "16 colours", style: Style{Fg: RGB(250, 10, 10), Bg: RGB(0, 0, 230)}, mode: Color16, want: "\x1b[0;91;44m"},
+		{name: "16 colours dim", style: Style{Fg: RGB(200, 0, 0)}, mode: Color16, want: "\x1b[0;31m"},
+		{name: "256 colours", style: Style{Fg: orange}, mode: Color256, want: "\x1b[0;38;5;208m"},
+		{name: "256 colours grey", style: Style{Bg: RGB(128, 128, 128)}, mode: Color256, want: "\x1b[0;48;5;244m"},
+		{name: "truecolor", style: Style{Fg: orange, Bg: RGB(1, 2, 3)}, mode: ColorTrue, want: "\x1b[0;38;2;255;128;0;48;2;1;2;3m"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := string(appendSGR(nil, tt.style, tt.mode)); got != tt.want {
+				t.Errorf("appendSGR() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderStyledMatchesRender(t *testing.T) {
+	font, err := LoadFont("fonts/standard.flf")
+	if err != nil {
+		t.Fatalf("LoadFont() error = %v", err)
+	}
+	optionSets := map[string][]Option{
+		"default":        nil,
+		"trimmed":        {WithTrimWhitespace(true)},
+		"wrapped":        {WithWidth(30), WithTrimWhitespace(true)},
+		"centred":        {WithWidth(70), WithJustify(JustifyCenter)},
+		"right to left":  {WithPrintDirection(1)},
+		"vertical smush": {WithVerticalLayout(ModeSmushingUniversal, 0)},
+	}
+	styles := map[string][]Option{
+		"rainbow":    {WithRainbow()},
+		"gradient":   {WithGradient(RGB(255, 0, 0), RGB(0, 0, 255), GradientVertical)},
+		"background": {WithGlyphStyles(Style{Bg: RGB(0, 0, 128), Underline: true}, Style{})},
+		"layered":    {WithRainbow(), WithStyle(func(CellInfo) Style { return Style{Bold: true} })},
+	}
+
+	for setName, opts := range optionSets {
+		for styleName, styleOpts := range styles {
+			for _, mode := range []ColorMode{Color16, Color256, ColorTrue} {
+				text := "Hello World\nfoo bar"
+				want, err := Render(text, font, opts...)
+				if err != nil {
+					t.Fatalf("Render() error = %v", err)
+				}
+				all := append(append(append([]Option(nil), opts...), styleOpts...), WithColorMode(mode))
+				got, err := Render(text, font, all...)
+				if err != nil {
+					t.Fatalf("Render() error = %v", err)
+				}
+				var sb strings.Builder
+				if err := RenderTo(&sb, text, font, all...); err != nil {
+					t.Fatalf("RenderTo() error = %v", err)
+				}
+				if sb.String() != got {
+					t.Errorf("%s, %s: RenderTo() and Render() differ", setName, styleName)
+				}
+
+				if plain := sgrPattern.ReplaceAllString(got, ""); plain != want {
+					t.Errorf("%s, %s, mode %d: without escapes =\n%s\nwant:\n%s", setName, styleName, mode, plain, want)
+				}
+				for _, line := range strings.Split(got, "\n") {
+					// Every style is reset by the end of the line, and no
+					// escape is written without text following it
+					escapes := sgrPattern.FindAllString(line, -1)
+					if len(escapes) > 0 && escapes[len(escapes)-1] != "\x1b[0m" {
+						t.Errorf("%s, %s: line %q is not reset", setName, styleName, line)
+					}
+					if danglingPattern.MatchString(strings.TrimSuffix(line, "\x1b[0m")) {
+						t.Errorf("%s, %s: line %q has dangling escapes", setName, styleName, line)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestRenderStyledCells(t *testing.T) {
+	font, err := LoadFont("fonts/standard.flf")
+	if err != nil {
+		t.Fatalf("LoadFont() error = %v", err)
+	}
+	grid, err := RenderGrid("Hello", font)
+	if err != nil {
+		t.Fatalf("RenderGrid() error = %v", err)
+	}
+
+	// Each cell's style is given the cell's grid position and source
+	var calls int
+	_, err = Render("Hello", font, WithStyle(func(c CellInfo) Style {
+		calls++
+		if want, ok := grid.At(c.Row, c.Col); !ok || c.Cell != want {
+			t.Errorf("CellInfo at (%d, %d) = %+v, want %+v", c.Row, c.Col, c.Cell, want)
+		}
+		if c.Height != len(grid.Rows) || c.Width < len(grid.Rows[c.Row]) {
+			t.Errorf("CellInfo size = %dx%d", c.Width, c.Height)
+		}
+		return Style{}
+	}))
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	cells := 0
+	for _, row := range grid.Rows {
+		cells += len(row)
+	}
+	if calls != cells {
+		t.Errorf("style function called %d times, want once for each of %d cells", calls, cells)
+	}
+}
+
+func TestRenderStyledModes(t *testing.T) {
+	font, err := LoadFont("fonts/standard.flf")
+	if err != nil {
+		t.Fatalf("LoadFont() error = %v", err)
+	}
+	plain, err := Render("Hi", font)
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	red, blue := RGB(255, 0, 0), RGB(0, 0, 255)
+
+	// ColorNone ignores styles
+	got, err := Render("Hi", font, WithRainbow(), WithColorMode(ColorNone))
+	if err != nil || got != plain {
+		t.Errorf("Render(ColorNone) = %q, %v, want %q", got, err, plain)
+	}
+
+	// A horizontal gradient runs from the first column to the last
+	got, err = Render("Hi", font, WithGradient(red, blue, GradientHorizontal), WithColorMode(ColorTrue))
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	first := strings.Split(got, "\n")[1] // starts with a visible character
+	if !strings.HasPrefix(first, "\x1b[0;38;2;255;0;0m") {
+		t.Errorf("gradient row %q does not start red", first)
+	}
+	if !strings.Contains(got, "\x1b[0;38;2;0;0;255m") {
+		t.Errorf("gradient %q does not reach blue", got)
+	}
+
+	if _, err := Render("Hi", font, WithRainbow(), WithColorMode(ColorMode(7))); !errors.Is(err, ErrInvalidColorMode) {
+		t.Errorf("Render() error = %v, want %v", err, ErrInvalidColorMode)
+	}
+}
+
+func TestStyleOver(t *testing.T) {
+	red, blue := RGB(255, 0, 0), RGB(0, 0, 255)
+	base := Style{Fg: red, Bg: blue, Bold: true}
+
+	got := Style{Fg: blue, Underline: true}.over(base)
+	want := Style{Fg: blue, Bg: blue, Bold: true, Underline: true}
+	if got != want {
+		t.Errorf("over() = %+v, want %+v", got, want)
+	}
+	if got := (Style{}).over(base); got != base {
+		t.Errorf("empty over() = %+v, want %+v", got, base)
+	}
+}
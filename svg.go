@@ -0,0 +1,195 @@
+package figgo
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// SVG layout defaults
+const (
+	defaultSVGCellWidth  = 10
+	defaultSVGCellHeight = 20
+	defaultSVGFontFamily = "monospace"
+
+	// svgFontScale is the font size as a fraction of the cell height, and
+	// svgBaseline the baseline's distance down a cell
+	svgFontScale = 0.8
+	svgBaseline  = 0.75
+)
+
+// svgOptions holds the settings of RenderSVG.
+type svgOptions struct {
+	cellWidth, cellHeight float64
+	fontFamily            string
+	fg, bg                Color
+}
+
+// WithSVGCellSize sets the size of each character cell of RenderSVG output,
+// in SVG user units; the default is 10 by 20. The font size is 80% of the
+// cell height, and text is stretched or squeezed to fill each cell's width.
+// Sizes that are not positive are ignored.
+func WithSVGCellSize(width, height float64) Option {
+	return func(opts *options) {
+		if width > 0 && height > 0 {
+			opts.svg.cellWidth, opts.svg.cellHeight = width, height
+		}
+	}
+}
+
+// WithSVGFontFamily sets the CSS font family of RenderSVG output; the
+// default is "monospace".
+func WithSVGFontFamily(family string) Option {
+	return func(opts *options) {
+		opts.svg.fontFamily = family
+	}
+}
+
+// WithSVGColors sets the text and background colours of RenderSVG output.
+// The default Color gives black text on a transparent background. Style
+// options (WithStyle, WithRainbow, ...) colour cells over these.
+func WithSVGColors(fg, bg Color) Option {
+	return func(opts *options) {
+		opts.svg.fg, opts.svg.bg = fg, bg
+	}
+}
+
+// RenderSVG writes text rendered as Render would as a self-contained SVG
+// document. Each output cell is placed on a grid of fixed-size cells, so the
+// art lines up whatever monospace font the viewer has, and the viewBox
+// covers the whole output. The document is labelled with the original text
+// for screen readers.
+//
+// Style options give per-cell colours: foreground colours become the fill
+// of the text, background colours a rectangle behind the cell, and bold,
+// italic and underline the matching font attributes. Layout, wrapping and
+// every other option behave exactly as for Render.
+//
+// Example:
+//
+//	err := figgo.RenderSVG(w, "Hello", font,
+//	    figgo.WithSVGCellSize(12, 24), figgo.WithRainbow())
+func RenderSVG(w io.Writer, text string, f *Font, opts ...Option) error {
+	if f == nil {
+		return ErrUnknownFont
+	}
+	options, err := resolveOptions(f, opts)
+	if err != nil {
+		return err
+	}
+	rows, err := renderCells(text, f, options)
+	if err != nil {
+		return err
+	}
+
+	svg := options.svg
+	if svg.cellWidth == 0 {
+		svg.cellWidth, svg.cellHeight = defaultSVGCellWidth, defaultSVGCellHeight
+	}
+	if svg.fontFamily == "" {
+		svg.fontFamily = defaultSVGFontFamily
+	}
+	fg := svg.fg
+	if fg == 0 {
+		fg = RGB(0, 0, 0)
+	}
+
+	width := gridWidth(rows)
+	docWidth := svgNumber(float64(width) * svg.cellWidth)
+	docHeight := svgNumber(float64(len(rows)) * svg.cellHeight)
+	label := escapeHTML(strings.Join(strings.Fields(text), " "))
+
+	var buf []byte
+	buf = fmt.Appendf(buf, "<svg xmlns=\"http://www.w3.org/2000/svg\" width=\"%s\" height=\"%s\" viewBox=\"0 0 %s %s\" role=\"img\" aria-label=\"%s\">\n",
+		docWidth, docHeight, docWidth, docHeight, label)
+	buf = fmt.Appendf(buf, "<title>%s</title>\n", label)
+	if svg.bg != 0 {
+		buf = fmt.Appendf(buf, "<rect width=\"100%%\" height=\"100%%\" fill=\"%s\"/>\n", svg.bg)
+	}
+
+	// Cell styles, worked out once for the backgrounds and the text
+	styled := options.styled()
+	styles := make([][]Style, len(rows))
+	for i, row := range rows {
+		styles[i] = make([]Style, len(row))
+		if !styled {
+			continue
+		}
+		var cur Style
+		for j, c := range row {
+			cur = options.cellStyle(CellInfo{Cell: Cell(c), Row: i, Col: j, Width: width, Height: len(rows)}, cur)
+			styles[i][j] = cur
+		}
+	}
+
+	// Backgrounds, one rectangle per run of cells
+	for i, row := range styles {
+		for j := 0; j < len(row); {
+			n := 1
+			for j+n < len(row) && row[j+n].Bg == row[j].Bg {
+				n++
+			}
+			if row[j].Bg != 0 {
+				buf = fmt.Appendf(buf, "<rect x=\"%s\" y=\"%s\" width=\"%s\" height=\"%s\" fill=\"%s\"/>\n",
+					svgNumber(float64(j)*svg.cellWidth), svgNumber(float64(i)*svg.cellHeight),
+					svgNumber(float64(n)*svg.cellWidth), svgNumber(svg.cellHeight), row[j].Bg)
+			}
+			j += n
+		}
+	}
+
+	// Text, one tspan per run of visible cells in the same style. Spaces are
+	// left out; each run is placed at its own column
+	buf = fmt.Appendf(buf, "<text font-family=\"%s\" font-size=\"%s\" fill=\"%s\">\n",
+		escapeHTML(svg.fontFamily), svgNumber(svg.cellHeight*svgFontScale), fg)
+	for i, row := range rows {
+		y := svgNumber((float64(i) + svgBaseline) * svg.cellHeight)
+		for j := 0; j < len(row); {
+			if row[j].Rune == ' ' {
+				j++
+				continue
+			}
+			s := styles[i][j]
+			n := 1
+			for j+n < len(row) && row[j+n].Rune != ' ' && styles[i][j+n] == s {
+				n++
+			}
+			buf = fmt.Appendf(buf, "<tspan x=\"%s\" y=\"%s\" textLength=\"%s\" lengthAdjust=\"spacingAndGlyphs\"%s>",
+				svgNumber(float64(j)*svg.cellWidth), y, svgNumber(float64(n)*svg.cellWidth), svgStyleAttrs(s))
+			for _, c := range row[j : j+n] {
+				buf = appendEscapedRune(buf, c.Rune)
+			}
+			buf = append(buf, "</tspan>\n"...)
+			j += n
+		}
+	}
+	buf = append(buf, "</text>\n</svg>\n"...)
+
+	_, err = w.Write(buf)
+	return err
+}
+
+// svgStyleAttrs returns the attributes drawing text in style s.
+func svgStyleAttrs(s Style) string {
+	var attrs string
+	if s.Fg != 0 {
+		attrs += fmt.Sprintf(" fill=\"%s\"", s.Fg)
+	}
+	if s.Bold {
+		attrs += " font-weight=\"bold\""
+	}
+	if s.Italic {
+		attrs += " font-style=\"italic\""
+	}
+	if s.Underline {
+		attrs += " text-decoration=\"underline\""
+	}
+	return attrs
+}
+
+// svgNumber formats a length to at most two decimal places.
+func svgNumber(v float64) string {
+	return strconv.FormatFloat(math.Round(v*100)/100, 'f', -1, 64)
+}
@@ -0,0 +1,178 @@
+package figgo
+
+import (
+	"encoding/xml"
+	"errors"
+	"flag"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+var updateSVG = flag.Bool("update-svg", false, "rewrite the SVG golden files in testdata/svg")
+
+func TestRenderSVGGolden(t *testing.T) {
+	tests := []struct {
+		name string
+		font string
+		text string
+		opts []Option
+	}{
+		{name: "default", font: "standard", text: "Hi!"},
+		{name: "escaped", font: "standard", text: "<&>", opts: []Option{WithLayout(FitFullWidth)}},
+		{name: "cell_size", font: "small", text: "figgo", opts: []Option{WithSVGCellSize(8.5, 17), WithSVGFontFamily("'Fira Code', monospace")}},
+		{name: "colors", font: "slant", text: "ok", opts: []Option{WithSVGColors(RGB(255, 255, 255), RGB(16, 16, 32))}},
+		{
+			name: "glyph_styles",
+			font: "big",
+			text: "abc",
+			opts: []Option{WithGlyphStyles(Style{Fg: RGB(255, 0, 0), Bold: true}, Style{Bg: RGB(0, 0, 128), Fg: RGB(255, 255, 255)}, Style{Italic: true})},
+		},
+		{name: "wrapped", font: "standard", text: "Hello World", opts: []Option{WithWidth(40), WithJustify(JustifyCenter), WithRainbow()}},
+		{name: "empty", font: "standard", text: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			font, err := LoadFont("fonts/" + tt.font + ".flf")
+			if err != nil {
+				t.Fatalf("LoadFont() error = %v", err)
+			}
+			var sb strings.Builder
+			if err := RenderSVG(&sb, tt.text, font, tt.opts...); err != nil {
+				t.Fatalf("RenderSVG() error = %v", err)
+			}
+			got := sb.String()
+
+			path := filepath.Join("testdata", "svg", tt.name+".svg")
+			if *updateSVG {
+				if err := os.WriteFile(path, []byte(got), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			want, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatalf("read golden file (run with -update-svg to create it): %v", err)
+			}
+			if got != string(want) {
+				t.Errorf("RenderSVG() differs from %s:\n%s", path, got)
+			}
+		})
+	}
+}
+
+// svgDoc is the part of a RenderSVG document the tests read back.
+type svgDoc struct {
+	ViewBox string `xml:"viewBox,attr"`
+	Label   string `xml:"aria-label,attr"`
+	Title   string `xml:"title"`
+	Spans   []struct {
+		X    string `xml:"x,attr"`
+		Y    string `xml:"y,attr"`
+		Text string `xml:",chardata"`
+	} `xml:"text>tspan"`
+}
+
+func TestRenderSVGMatchesRender(t *testing.T) {
+	optionSets := map[string][]Option{
+		"default":       nil,
+		"right to left": {WithPrintDirection(1)},
+		"wrapped":       {WithWidth(30), WithTrimWhitespace(true)},
+		"centred":       {WithWidth(70), WithJustify(JustifyCenter), WithRainbow()},
+		"cell size":     {WithSVGCellSize(7, 13)},
+	}
+	texts := []string{"Hello World", "<a href='x'> & \"y\"", "one\ntwo"}
+
+	for _, name := range []string{"standard", "big", "slant", "small"} {
+		font, err := LoadFont("fonts/" + name + ".flf")
+		if err != nil {
+			t.Fatalf("LoadFont() error = %v", err)
+		}
+		for setName, opts := range optionSets {
+			for _, text := range texts {
+				plain, err := Render(text, font, append(opts, WithColorMode(ColorNone))...)
+				if err != nil {
+					t.Fatalf("Render() error = %v", err)
+				}
+				// Spaces are not drawn, so trailing ones are lost
+				lines := strings.Split(plain, "\n")
+				for i, line := range lines {
+					lines[i] = strings.TrimRight(line, " ")
+				}
+				want := strings.Join(lines, "\n")
+				var sb strings.Builder
+				if err := RenderSVG(&sb, text, font, opts...); err != nil {
+					t.Fatalf("RenderSVG() error = %v", err)
+				}
+
+				var doc svgDoc
+				if err := xml.Unmarshal([]byte(sb.String()), &doc); err != nil {
+					t.Fatalf("%s, %s, %q: RenderSVG() is not well-formed: %v", name, setName, text, err)
+				}
+				if got := svgText(t, doc, opts); got != want {
+					t.Errorf("%s, %s, %q: RenderSVG() text =\n%s\nwant:\n%s", name, setName, text, got, want)
+				}
+				if doc.Label != strings.Join(strings.Fields(text), " ") || doc.Title != doc.Label {
+					t.Errorf("%s, %s, %q: label = %q, title = %q", name, setName, text, doc.Label, doc.Title)
+				}
+			}
+		}
+	}
+}
+
+// svgText places the text of each tspan back on the character grid.
+func svgText(t *testing.T, doc svgDoc, opts []Option) string {
+	t.Helper()
+	o := defaultOptions()
+	for _, opt := range opts {
+		opt(o)
+	}
+	cellWidth, cellHeight := o.svg.cellWidth, o.svg.cellHeight
+	if cellWidth == 0 {
+		cellWidth, cellHeight = defaultSVGCellWidth, defaultSVGCellHeight
+	}
+
+	fields := strings.Fields(doc.ViewBox)
+	height, _ := strconv.ParseFloat(fields[3], 64)
+	rows := make([][]rune, int(height/cellHeight+0.5))
+	for _, span := range doc.Spans {
+		x, _ := strconv.ParseFloat(span.X, 64)
+		y, _ := strconv.ParseFloat(span.Y, 64)
+		row, col := int(y/cellHeight), int(x/cellWidth+0.5)
+		for _, r := range span.Text {
+			for len(rows[row]) <= col {
+				rows[row] = append(rows[row], ' ')
+			}
+			rows[row][col] = r
+			col++
+		}
+	}
+	lines := make([]string, len(rows))
+	for i, row := range rows {
+		lines[i] = string(row)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func TestRenderSVGErrors(t *testing.T) {
+	font, err := LoadFont("fonts/standard.flf")
+	if err != nil {
+		t.Fatalf("LoadFont() error = %v", err)
+	}
+	var sb strings.Builder
+
+	if err := RenderSVG(&sb, "Hi", nil); err != ErrUnknownFont {
+		t.Errorf("RenderSVG(nil font) error = %v, want %v", err, ErrUnknownFont)
+	}
+	if err := RenderSVG(&sb, "Hi", font, WithJustify(Justify(9))); !errors.Is(err, ErrInvalidJustify) {
+		t.Errorf("RenderSVG() error = %v, want %v", err, ErrInvalidJustify)
+	}
+	if err := RenderSVG(&sb, "∑", font); err == nil {
+		t.Error("RenderSVG() expected error for an unsupported rune")
+	}
+	if sb.Len() != 0 {
+		t.Errorf("RenderSVG() wrote %q on error", sb.String())
+	}
+}
@@ -17,10 +17,11 @@ type Font struct {
 	// Name is the font name (e.g., "standard")
 	Name string
 
-	// Layout contains the normalized horizontal layout bitmask combining fitting mode and smushing rules.
-	// This is the processed layout derived from the font header's OldLayout/FullLayout values.
-	// See NormalizeLayoutFromHeader for the normalization process.
-	// Only horizontal layout is currently used by the renderer.
+	// Layout contains the normalized layout bitmask combining fitting modes and smushing rules
+	// for both axes. This is the processed layout derived from the font header's
+	// OldLayout/FullLayout values. See NormalizeLayoutFromHeader for the normalization process.
+	// By default only the horizontal bits are used by the renderer; pass the layout to
+	// WithLayout to stack output lines with the vertical bits as well.
 	Layout Layout
 
 	// Hardblank is the character used for hard blanks in the font
@@ -43,11 +44,31 @@ type Font struct {
 
 	// CommentLines is the number of comment lines in the font file
 	CommentLines int
+
+	// comments holds the header comment lines, kept so the font can be written back out
+	comments []string
+
+	// signature is the header signature ("flf2a" or "tlf2a"), empty for built fonts
+	signature string
+
+	// fullLayout is the header FullLayout value, valid when fullLayoutSet is true
+	fullLayout    int
+	fullLayoutSet bool
+
+	// codetagCount is the header Codetag_Count value, valid when codetagCountSet is true
+	codetagCount    int
+	codetagCountSet bool
+
+	// diagnostics holds the recoverable problems found while parsing
+	diagnostics []Diagnostic
 }
 
 // Glyph returns the ASCII art representation for a rune, or false if not found.
 // This method is safe for concurrent use. The returned slice is immutable and
 // must not be modified by the caller.
+//
+// Code-tagged glyphs are included, so negative codes (such as translation
+// tables) and code 0, the font's "missing character", can be looked up too.
 func (f *Font) Glyph(r rune) ([]string, bool) {
 	if f == nil || f.glyphs == nil {
 		return nil, false
@@ -66,6 +87,28 @@ var (
 
 	// ErrBadFontFormat is returned when a font file has an invalid format
 	ErrBadFontFormat = errors.New("bad font format")
+
+	// ErrInvalidJustify is returned when WithJustify is given an unknown value
+	ErrInvalidJustify = errors.New("invalid justify")
+
+	// ErrInvalidWrapMode is returned when WithWrapMode is given an unknown mode
+	ErrInvalidWrapMode = errors.New("invalid wrap mode")
+
+	// ErrNoFit is returned by RenderFit when no candidate fits the width
+	ErrNoFit = errors.New("text does not fit the width")
+
+	// ErrInvalidColor is returned by ParseColor for an unrecognised colour
+	ErrInvalidColor = errors.New("invalid color")
+
+	// ErrInvalidColorMode is returned when WithColorMode is given an unknown mode
+	ErrInvalidColorMode = errors.New("invalid color mode")
+
+	// ErrInvalidHTMLElement is returned by RenderHTML for a malformed
+	// wrapper element name
+	ErrInvalidHTMLElement = errors.New("invalid HTML element")
+
+	// ErrInvalidPixelMode is returned when WithPixelMode is given an unknown mode
+	ErrInvalidPixelMode = errors.New("invalid pixel mode")
 )
 
 // WithLayout sets the layout mode for rendering, overriding the font's default.
@@ -74,12 +117,16 @@ var (
 // - Completely replaces the font's built-in layout settings
 // - Can change fitting mode (full-width, kerning, smushing)
 // - Can enable/disable specific smushing rules
+// - Can set the vertical fitting mode and rules, which otherwise default to
+//   full height even when the font's layout includes them
 // - Layout validation occurs during rendering (will return error if invalid)
 //
 // Common Usage:
 //   - WithLayout(FitFullWidth): Force full-width spacing
 //   - WithLayout(FitKerning): Use minimal spacing without overlap
 //   - WithLayout(FitSmushing | RuleEqualChar): Enable equal character smushing only
+//   - WithLayout(font.Layout): Use the font's layout on both axes
+//   - WithLayout(FitSmushing | FitVerticalFitting): Smush, and fit lines vertically
 //
 // The layout will be normalized and validated when rendering begins.
 func WithLayout(layout Layout) Option {
@@ -112,6 +159,7 @@ func WithPrintDirection(direction int) Option {
 // Default is '?' when not set.
 //
 // Error Handling Strategy:
+// - A font's own "missing character" (code 0) is used first, as in C figlet
 // - Without this option: rendering fails with ErrUnsupportedRune
 // - With this option: unknown runes are replaced with the specified rune
 // - The replacement rune must exist in the font, or rendering will still fail
@@ -191,6 +239,148 @@ func WithWidth(width int) Option {
 	}
 }
 
+// WrapMode is how lines wider than the output width are broken.
+type WrapMode int
+
+// Wrap modes
+const (
+	// WrapWordThenChar breaks lines at spaces, and breaks words wider than a
+	// whole line at glyph boundaries, as FIGlet does (the default)
+	WrapWordThenChar WrapMode = iota
+	// WrapWord breaks lines only at spaces; a word wider than a whole line
+	// runs past the width
+	WrapWord
+	// WrapChar fills each line to the width, breaking at any glyph boundary
+	WrapChar
+)
+
+// WithWrapMode sets how lines are broken at the width set by WithWidth.
+// WrapWordThenChar and WrapChar guarantee that no line is wider than the
+// width (a single glyph wider than the line is cut); WrapWord keeps words
+// whole at the cost of that guarantee. An unknown mode makes rendering
+// return ErrInvalidWrapMode.
+//
+// Example:
+//
+//	// Fill a fixed-width status board, breaking URLs anywhere
+//	figgo.Render(url, font, figgo.WithWidth(40), figgo.WithWrapMode(figgo.WrapChar))
+func WithWrapMode(mode WrapMode) Option {
+	return func(opts *options) {
+		opts.wrapMode = mode
+	}
+}
+
+// WithHyphenation ends lines that break inside a word with the font's '-'
+// glyph, moving characters to the next line as needed to make room for it.
+// It has no effect if the font has no '-' glyph, or for words broken just
+// after a '-'.
+func WithHyphenation(enabled bool) Option {
+	return func(opts *options) {
+		opts.hyphenate = enabled
+	}
+}
+
+// WithBalancedWrap breaks lines so they come out as even as possible,
+// instead of filling each line before starting the next. Lines are measured
+// with the font's real kerning and smushing, and the breaks minimise the
+// number of lines and then the squared free space on each line
+// (Knuth–Plass style minimum raggedness), so the output is deterministic.
+//
+// Balancing only moves breaks between words; a word wider than a whole
+// line is still broken according to WithWrapMode. Without WithWidth the
+// text fits on one line and nothing changes.
+//
+// Example:
+//
+//	// A two-line title instead of a full line and a lone word
+//	figgo.Render("Release notes for v2", font, figgo.WithWidth(60), figgo.WithBalancedWrap(true))
+func WithBalancedWrap(enabled bool) Option {
+	return func(opts *options) {
+		opts.balancedWrap = enabled
+	}
+}
+
+// WithParagraphMode reflows multi-line input like FIGlet's -p flag. A single
+// newline is treated as a space, so a paragraph wraps at the WithWidth limit
+// as one run of words; a newline followed by whitespace (a blank or indented
+// line) or by another newline still breaks the line.
+//
+// Example:
+//
+//	// Wrap a hard-wrapped log message as one paragraph
+//	figgo.Render("disk almost\nfull", font, figgo.WithWidth(60), figgo.WithParagraphMode(true))
+func WithParagraphMode(enabled bool) Option {
+	return func(opts *options) {
+		opts.paragraphMode = enabled
+	}
+}
+
+// Justify is the horizontal alignment of each output line.
+type Justify int
+
+// Justification values
+const (
+	// JustifyLeft starts each line at column 0 (the default)
+	JustifyLeft Justify = iota
+	// JustifyCenter centres each line within the output width
+	JustifyCenter
+	// JustifyRight ends each line at the output width
+	JustifyRight
+	// JustifyAuto right-justifies right-to-left text and left-justifies
+	// everything else, like FIGlet's -x
+	JustifyAuto
+)
+
+// WithJustify aligns each output line within the output width, matching
+// FIGlet's -l, -c, -r and -x flags. The width is the one set by WithWidth,
+// or 80 if none is set; lines wider than it are not moved.
+//
+// All rows of a FIGlet line get the same padding, so the art stays intact.
+// An unknown value makes rendering return ErrInvalidJustify.
+//
+// Example:
+//
+//	// Centre a banner in a 60 column terminal
+//	figgo.Render("Hello", font, figgo.WithWidth(60), figgo.WithJustify(figgo.JustifyCenter))
+func WithJustify(j Justify) Option {
+	return func(opts *options) {
+		opts.justify = j
+	}
+}
+
+// WithVerticalLayout sets how consecutive output lines are stacked when the
+// text contains newlines or is wrapped. By default lines are stacked at full
+// height, as FIGlet 2.2 does.
+//
+// Modes:
+//   - ModeFull: Each line starts below the last row of the one above
+//   - ModeFitting: Each line moves up until it would touch the one above
+//   - ModeSmushingControlled: Lines overlap by one more row, merging touching
+//     characters with the vertical rules; if a pair cannot be merged, the
+//     lines are only fitted
+//   - ModeSmushingUniversal: Lines overlap by one more row, and the lower
+//     line's characters win
+//
+// rules selects the vertical smushing rules for ModeSmushingControlled, as
+// bits 0-4 like NormalizedLayout.VertRules: 1 equal character, 2 underscore,
+// 4 hierarchy, 8 horizontal line ("-" and "_" make "=") and 16 vertical line
+// supersmushing. Controlled smushing without rules is universal smushing.
+// An unknown mode makes rendering return ErrInvalidAxisMode.
+//
+// WithVerticalLayout takes precedence over vertical bits passed to WithLayout.
+//
+// Example:
+//
+//	// Stack lines the way the font's FullLayout asks for
+//	nl := font.NormalizedLayout()
+//	figgo.Render("Hello\nWorld", font, figgo.WithVerticalLayout(nl.VertMode, nl.VertRules))
+func WithVerticalLayout(mode AxisMode, rules uint8) Option {
+	return func(opts *options) {
+		opts.verticalMode = &mode
+		opts.verticalRules = rules & vertRuleMask
+	}
+}
+
 // WithDebug enables debug tracing for this render operation.
 // The provided debug session will receive detailed events about the rendering
 // process including layout decisions, smushing calculations, and glyph lookups.
@@ -174,3 +174,67 @@ func TestPrintDirectionOverride(t *testing.T) {
 	_ = output1
 	_ = output2
 }
+
+func TestMissingCharacterGlyph(t *testing.T) {
+	// Font defines code 0, the spec's "missing character", and a negative-code
+	// translation table glyph
+	glyphs := map[rune][]string{
+		' ': {" ", " "},
+		'A': {"A", "A"},
+		'?': {"?", "?"},
+		0:   {"#", "#"},
+		-2:  {"t", "t"},
+	}
+
+	mockFont := &Font{
+		glyphs: glyphs,
+		Height: 2,
+		Layout: FitFullWidth,
+	}
+
+	// The font's missing character wins over WithUnknownRune
+	output, err := Render("A世A", mockFont, WithUnknownRune('?'))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := "A#A\nA#A"; output != want {
+		t.Errorf("Render() = %q, want %q", output, want)
+	}
+
+	// No error even without WithUnknownRune
+	if _, err := Render("世", mockFont); err != nil {
+		t.Errorf("unexpected error without WithUnknownRune: %v", err)
+	}
+
+	// Negative codes are reachable through Glyph
+	if glyph, ok := mockFont.Glyph(-2); !ok || glyph[0] != "t" {
+		t.Errorf("Glyph(-2) = %v, %v; want translation table glyph", glyph, ok)
+	}
+}
+
+func TestMissingCharacterGlyphFromFont(t *testing.T) {
+	var sb strings.Builder
+	sb.WriteString("flf2a$ 1 1 4 -1 0\n")
+	for i := 32; i <= 126; i++ {
+		sb.WriteString(string(rune(i)) + "@@\n")
+	}
+	sb.WriteString(strings.Repeat("D@@\n", 7)) // Deutsch characters
+	sb.WriteString("0  missing character\n~~@@\n-2  translation table\nT@@\n")
+
+	font, err := ParseFontBytes([]byte(sb.String()))
+	if err != nil {
+		t.Fatalf("ParseFontBytes() error = %v", err)
+	}
+
+	output, err := Render("aéb", font)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := "a~~b"; output != want {
+		t.Errorf("Render() = %q, want %q", output, want)
+	}
+
+	if glyph, ok := font.Glyph(-2); !ok || glyph[0] != "T" {
+		t.Errorf("Glyph(-2) = %v, %v; want negative-code glyph carried through", glyph, ok)
+	}
+}
@@ -0,0 +1,121 @@
+package figgo
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestWithVerticalLayout(t *testing.T) {
+	font, err := LoadFont("fonts/standard.flf")
+	if err != nil {
+		t.Fatalf("LoadFont() error = %v", err)
+	}
+	const text = "Hey\nyou"
+
+	full, err := Render(text, font)
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	explicitFull, err := Render(text, font, WithVerticalLayout(ModeFull, 0))
+	if err != nil {
+		t.Fatalf("Render(ModeFull) error = %v", err)
+	}
+	if explicitFull != full {
+		t.Errorf("ModeFull output differs from the default:\n%s\nwant:\n%s", explicitFull, full)
+	}
+
+	rows := func(s string) int { return strings.Count(s, "\n") + 1 }
+	nl := font.NormalizedLayout()
+	tests := []struct {
+		name     string
+		mode     AxisMode
+		rules    uint8
+		wantRows int
+	}{
+		// "Hey" ends with the descender of "y" and a blank row; "you" starts with a blank row
+		{name: "fitting", mode: ModeFitting, wantRows: 11},
+		{name: "controlled smushing", mode: ModeSmushingControlled, rules: nl.VertRules, wantRows: 10},
+		{name: "controlled without rules is universal", mode: ModeSmushingControlled, wantRows: 10},
+		{name: "universal smushing", mode: ModeSmushingUniversal, wantRows: 10},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Render(text, font, WithVerticalLayout(tt.mode, tt.rules))
+			if err != nil {
+				t.Fatalf("Render() error = %v", err)
+			}
+			if rows(got) != tt.wantRows {
+				t.Errorf("got %d rows, want %d:\n%s", rows(got), tt.wantRows, got)
+			}
+			// The first line's top rows are unchanged
+			if !strings.HasPrefix(got, strings.Join(strings.SplitN(full, "\n", 5)[:4], "\n")) {
+				t.Errorf("top of output changed:\n%s", got)
+			}
+		})
+	}
+
+	if _, err := Render(text, font, WithVerticalLayout(AxisMode(9), 0)); !errors.Is(err, ErrInvalidAxisMode) {
+		t.Errorf("Render() error = %v, want ErrInvalidAxisMode", err)
+	}
+}
+
+func TestLayoutVerticalBits(t *testing.T) {
+	font, err := LoadFont("fonts/standard.flf")
+	if err != nil {
+		t.Fatalf("LoadFont() error = %v", err)
+	}
+	const text = "Hey\nyou"
+
+	// standard's FullLayout asks for vertical smushing, which only applies on request
+	if got := font.Layout.VerticalFittingMode(); got != FitVerticalSmushing {
+		t.Fatalf("font.Layout = %v, want vertical smushing", font.Layout)
+	}
+
+	render := func(opts ...Option) string {
+		t.Helper()
+		out, err := Render(text, font, opts...)
+		if err != nil {
+			t.Fatalf("Render() error = %v", err)
+		}
+		return out
+	}
+
+	full := render(WithVerticalLayout(ModeFull, 0))
+	nl := font.NormalizedLayout()
+	smushed := render(WithVerticalLayout(nl.VertMode, nl.VertRules))
+	fitted := render(WithVerticalLayout(ModeFitting, 0))
+
+	tests := []struct {
+		name string
+		opts []Option
+		want string
+	}{
+		{name: "font default is full height", want: full},
+		{name: "font layout via WithLayout", opts: []Option{WithLayout(font.Layout)}, want: smushed},
+		{
+			name: "vertical fitting via WithLayout",
+			opts: []Option{WithLayout(font.Layout&^(FitVerticalSmushing|font.Layout.VerticalRules()) | FitVerticalFitting)},
+			want: fitted,
+		},
+		{
+			name: "WithVerticalLayout takes precedence",
+			opts: []Option{WithLayout(font.Layout), WithVerticalLayout(ModeFitting, 0)},
+			want: fitted,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := render(tt.opts...); got != tt.want {
+				t.Errorf("output:\n%s\nwant:\n%s", got, tt.want)
+			}
+		})
+	}
+
+	_, err = Render(text, font, WithLayout(FitVerticalFitting|FitVerticalSmushing))
+	if !errors.Is(err, ErrLayoutConflict) {
+		t.Errorf("Render() error = %v, want ErrLayoutConflict", err)
+	}
+}
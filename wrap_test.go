@@ -0,0 +1,63 @@
+package figgo
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestWithWrapMode(t *testing.T) {
+	font, err := LoadFont("fonts/standard.flf")
+	if err != nil {
+		t.Fatalf("LoadFont() error = %v", err)
+	}
+	const width = 40
+	const text = "see https://example.com/a/very/long/path?query=1 now"
+
+	maxLine := func(s string) int {
+		longest := 0
+		for _, line := range strings.Split(s, "\n") {
+			longest = max(longest, len([]rune(line)))
+		}
+		return longest
+	}
+
+	// Modes that break words never exceed the width
+	for _, mode := range []WrapMode{WrapWordThenChar, WrapChar} {
+		for _, hyphenate := range []bool{false, true} {
+			got, err := Render(text, font, WithWidth(width), WithWrapMode(mode), WithHyphenation(hyphenate))
+			if err != nil {
+				t.Fatalf("Render() error = %v", err)
+			}
+			if n := maxLine(got); n >= width {
+				t.Errorf("mode %d hyphenate %v: widest line is %d columns, want < %d:\n%s", mode, hyphenate, n, width, got)
+			}
+		}
+	}
+
+	// WrapWordThenChar is the default
+	def, err := Render(text, font, WithWidth(width))
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	explicit, err := Render(text, font, WithWidth(width), WithWrapMode(WrapWordThenChar))
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	if def != explicit {
+		t.Errorf("WrapWordThenChar output differs from the default:\n%s\nwant:\n%s", explicit, def)
+	}
+
+	// WrapWord keeps the URL whole, past the width
+	word, err := Render(text, font, WithWidth(width), WithWrapMode(WrapWord))
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	if n := maxLine(word); n < width {
+		t.Errorf("WrapWord widest line is %d columns, want the URL past the width:\n%s", n, word)
+	}
+
+	if _, err := Render(text, font, WithWrapMode(WrapMode(-1))); !errors.Is(err, ErrInvalidWrapMode) {
+		t.Errorf("Render() error = %v, want ErrInvalidWrapMode", err)
+	}
+}